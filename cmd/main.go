@@ -2,20 +2,78 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/deliver"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <daily|weekly>\n", os.Args[0])
+	lang := flag.String("lang", "", "report languages, comma separated (zh, en); overrides languages in the config")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [--lang en|zh] <daily|weekly|courses [refresh]|contributors|feed|html <file.md> [out.html]|deliver <email|notify> <daily|weekly>>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 1 {
+		flag.Usage()
 		os.Exit(2)
 	}
+	cfg, err := config.Load(config.DefaultPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if *lang != "" {
+		if cfg.Languages, err = i18n.ParseList(*lang); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --lang: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	if args[0] == "feed" { // 只读取本地周报，不需要仓库列表
+		if err := report.Feed(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to generate feeds: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if args[0] == "html" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: %s html <file.md> [out.html]\n", os.Args[0])
+			os.Exit(2)
+		}
+		output := ""
+		if len(args) > 2 {
+			output = args[2]
+		}
+		if err := report.WriteHTML(args[1], output); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to render HTML: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if args[0] == "deliver" { // 投递已生成的报告，不需要仓库列表
+		if len(args) < 3 || (args[1] != "email" && args[1] != "notify") || (args[2] != report.ReportKindDaily && args[2] != report.ReportKindWeekly) {
+			fmt.Fprintf(os.Stderr, "Usage: %s deliver <email|notify> <daily|weekly>\n", os.Args[0])
+			os.Exit(2)
+		}
+		deliverTo := deliver.Email
+		if args[1] == "notify" {
+			deliverTo = deliver.Notify
+		}
+		if err := deliverTo(cfg, args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to deliver %s report via %s: %v\n", args[2], args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
 	publicRepos, err := github.LoadPublicRepos()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load public repos: %v\n", err)
@@ -23,15 +81,18 @@ func main() {
 	}
 	log.Printf("Fetched %d public repos", len(publicRepos))
 
-	switch os.Args[1] {
+	switch args[0] {
 	case "daily":
-		if err := report.Daily(config.OrgName, publicRepos); err != nil {
+		if err := report.Daily(config.OrgName, publicRepos, cfg); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to generate daily news: %v\n", err)
 			os.Exit(1)
 		}
+		if err := deliver.Notify(cfg, report.ReportKindDaily); err != nil { // 推送失败不影响已生成的日报
+			log.Printf("Failed to send notifications: %v", err)
+		}
 
 	case "weekly":
-		if err := report.Weekly(config.OrgName, publicRepos); err != nil {
+		if err := report.Weekly(config.OrgName, publicRepos, cfg); err != nil {
 			if errors.Is(err, report.ErrNoWeeklyCommits) {
 				log.Printf("Summary skipped: %v", err)
 				return
@@ -39,9 +100,35 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Failed to generate weekly summary: %v\n", err)
 			os.Exit(1)
 		}
+		if err := report.Feed(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to generate feeds: %v\n", err)
+			os.Exit(1)
+		}
+		if err := deliver.Notify(cfg, report.ReportKindWeekly); err != nil {
+			log.Printf("Failed to send notifications: %v", err)
+		}
+
+	case "courses":
+		if len(args) > 1 && args[1] == "refresh" {
+			if err := report.RefreshCourseMeta(config.OrgName, publicRepos, cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to refresh course metadata: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		if err := report.Courses(config.OrgName, publicRepos, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to generate course pages: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "contributors":
+		if err := report.Contributors(config.OrgName, publicRepos, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to generate contributor pages: %v\n", err)
+			os.Exit(1)
+		}
 
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\nUsage: go run cmd/main.go <daily|weekly>\n", os.Args[1])
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\nUsage: go run cmd/main.go [--lang en|zh] <daily|weekly|courses [refresh]|contributors|feed|html <file.md> [out.html]|deliver <email|notify> <daily|weekly>>\n", args[0])
 		os.Exit(2)
 	}
 }
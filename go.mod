@@ -2,4 +2,7 @@ module github.com/HITSZ-OpenAuto/hoa-news
 
 go 1.24.12
 
-require gopkg.in/yaml.v3 v3.0.1
+require (
+	github.com/pelletier/go-toml/v2 v2.2.4
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,80 @@
+// bot 账号识别：内置名单、配置名单、正则规则以及 GitHub API 返回的账号类型
+package bots
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// Config 对应配置文件中的 bots 段。
+type Config struct {
+	Names      []string         `yaml:"names"`      // 额外的 bot 作者名或登录名，不区分大小写
+	Patterns   []string         `yaml:"patterns"`   // 匹配作者名或登录名的正则
+	Automation AutomationConfig `yaml:"automation"` // 按提交模式识别自动化操作
+	ShowCount  bool             `yaml:"show_count"` // 是否在报告中显示被识别为自动化操作的提交数
+}
+
+// AutomationConfig 配置自动化操作识别：相同提交信息在相邻间隔不超过 Window 的时间内
+// 出现在至少 MinRepos 个仓库中时，视为脚本批量提交。MinRepos 为 0 时关闭。
+type AutomationConfig struct {
+	MinRepos int           `yaml:"min_repos"`
+	Window   time.Duration `yaml:"window"`
+}
+
+// Detector 判断提交作者是否为 bot。nil Detector 只使用内置名单。
+type Detector struct {
+	names    map[string]struct{}
+	patterns []*regexp.Regexp
+}
+
+// New 根据配置构建 Detector，正则无效时返回错误。
+func New(cfg Config) (*Detector, error) {
+	d := &Detector{names: make(map[string]struct{}, len(cfg.Names))}
+	for _, name := range cfg.Names {
+		d.names[strings.ToLower(strings.TrimSpace(name))] = struct{}{}
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bot pattern: %w", err)
+		}
+		d.patterns = append(d.patterns, re)
+	}
+	if cfg.Automation.MinRepos < 0 || cfg.Automation.Window < 0 {
+		return nil, fmt.Errorf("automation min_repos and window must not be negative")
+	}
+	return d, nil
+}
+
+// Check 判断作者是否为 bot，accountType 为 GitHub API 返回的账号类型（User、Bot 等），可能为空。
+// 是 bot 时 reason 说明命中的规则。
+func (d *Detector) Check(name, login, accountType string) (isBot bool, reason string) {
+	if strings.EqualFold(accountType, "Bot") {
+		return true, "account type is Bot"
+	}
+	if utils.IsBot(name, login) {
+		return true, "known bot"
+	}
+	if d == nil {
+		return false, ""
+	}
+	for _, s := range []string{name, login} {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if _, ok := d.names[strings.ToLower(s)]; ok {
+			return true, fmt.Sprintf("%q is listed in bots.names", s)
+		}
+		for _, re := range d.patterns {
+			if re.MatchString(s) {
+				return true, fmt.Sprintf("%q matches bot pattern %q", s, re.String())
+			}
+		}
+	}
+	return false, ""
+}
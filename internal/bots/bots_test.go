@@ -0,0 +1,45 @@
+package bots
+
+import "testing"
+
+func TestDetectorCheck(t *testing.T) {
+	d, err := New(Config{
+		Names:    []string{"HOA Sync"},
+		Patterns: []string{`^hoa-.*-sync$`},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name, login, accountType string
+		want                     bool
+	}{
+		{"张三", "zhangsan", "User", false},
+		{"Some App", "some-app", "Bot", true},
+		{"github-actions[bot]", "", "", true},
+		{"hoa sync", "", "", true},
+		{"同步脚本", "hoa-readme-sync", "User", true},
+		{"hoa-sync-helper", "", "", false},
+	}
+	for _, tt := range tests {
+		got, reason := d.Check(tt.name, tt.login, tt.accountType)
+		if got != tt.want {
+			t.Errorf("Check(%q, %q, %q) = %v, want %v", tt.name, tt.login, tt.accountType, got, tt.want)
+		}
+		if got && reason == "" {
+			t.Errorf("Check(%q, %q, %q) returned no reason", tt.name, tt.login, tt.accountType)
+		}
+	}
+
+	var nilDetector *Detector
+	if got, _ := nilDetector.Check("dependabot[bot]", "", ""); !got {
+		t.Errorf("nil detector should still use the built-in list")
+	}
+}
+
+func TestNew_RejectsInvalidPattern(t *testing.T) {
+	if _, err := New(Config{Patterns: []string{"("}}); err == nil {
+		t.Fatalf("expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,191 @@
+// 基于关键词和文件路径规则的 commit 分类
+package categorize
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Category 表示一条 commit 的类别。
+type Category string
+
+const (
+	Material    Category = "material"    // 新增资料
+	Correction  Category = "correction"  // 勘误修正
+	Docs        Category = "docs"        // 课程信息与文档
+	Review      Category = "review"      // 教师评价
+	Restructure Category = "restructure" // 结构调整
+	Trivial     Category = "trivial"     // 日常维护
+	Other       Category = "other"       // 无法归类
+)
+
+// Order 是渲染时各类别的展示顺序。
+var Order = []Category{Material, Correction, Review, Docs, Restructure, Other, Trivial}
+
+var titles = map[Category]string{
+	Material:    "新增资料",
+	Correction:  "勘误修正",
+	Docs:        "课程信息与文档",
+	Review:      "教师评价",
+	Restructure: "结构调整",
+	Trivial:     "日常维护",
+	Other:       "其他更新",
+}
+
+// Title 返回类别的中文标题，未知类别返回「其他更新」。
+func (c Category) Title() string {
+	if title, ok := titles[c]; ok {
+		return title
+	}
+	return titles[Other]
+}
+
+// Valid 报告 c 是否为已知类别。
+func (c Category) Valid() bool {
+	_, ok := titles[c]
+	return ok
+}
+
+// Rule 是一条分类规则。Keywords 中任一关键词出现在提交信息首行（不区分大小写）即命中，
+// 纯 ASCII 的关键词按单词匹配（允许 s、es、d、ed、ing 词尾，fix 不会命中 prefix），含中文等非 ASCII 字符的关键词按子串匹配；
+// 否则当 Paths 非空、提交的文件列表非空且每个文件都匹配 Paths 中的某个模式时命中。
+// 不含 "/" 的模式匹配文件名，以 "/" 结尾的模式匹配目录前缀，其余模式按 path.Match 匹配完整路径。
+type Rule struct {
+	Category Category `yaml:"category"`
+	Keywords []string `yaml:"keywords"`
+	Paths    []string `yaml:"paths"`
+}
+
+// DefaultRules 是内置的分类规则，按优先级排列。
+var DefaultRules = []Rule{
+	{
+		Category: Trivial,
+		Keywords: []string{"格式", "typo", "merge branch", "merge pull request", "gitignore", "删除旧", "删除无用", "删除多余"},
+		Paths:    []string{".gitignore", ".gitattributes", ".github/", "LICENSE"},
+	},
+	{
+		Category: Review,
+		Keywords: []string{"教师评价", "老师评价", "课程评价", "评价"},
+	},
+	{
+		Category: Correction,
+		Keywords: []string{"勘误", "订正", "修正", "更正", "纠正", "修复", "错误", "fix"},
+	},
+	{
+		Category: Restructure,
+		Keywords: []string{"重构", "重命名", "移动", "迁移", "整理", "调整目录", "rename"},
+	},
+	{
+		Category: Docs,
+		Keywords: []string{"课程信息", "学习建议", "readme", "说明", "文档", "toml"},
+		Paths:    []string{"README.md", "readme.toml", "*.md"},
+	},
+	{
+		Category: Material,
+		Keywords: []string{"新增", "添加", "增加", "上传", "补充", "add", "upload"},
+		Paths:    []string{"*.pdf", "*.doc", "*.docx", "*.ppt", "*.pptx", "*.xls", "*.xlsx", "*.zip", "*.rar", "*.7z", "*.png", "*.jpg", "*.jpeg"},
+	},
+}
+
+// Categorizer 按规则对 commit 分类。
+type Categorizer struct {
+	rules    []Rule
+	keywords [][]*regexp.Regexp // 与 rules 一一对应的关键词匹配式
+}
+
+// New 创建 Categorizer，rules 优先于 DefaultRules 生效。
+func New(rules []Rule) *Categorizer {
+	all := make([]Rule, 0, len(rules)+len(DefaultRules))
+	all = append(all, rules...)
+	all = append(all, DefaultRules...)
+	keywords := make([][]*regexp.Regexp, len(all))
+	for i, rule := range all {
+		for _, keyword := range rule.Keywords {
+			if keyword != "" {
+				keywords[i] = append(keywords[i], compileKeyword(keyword))
+			}
+		}
+	}
+	return &Categorizer{rules: all, keywords: keywords}
+}
+
+// compileKeyword 将关键词转换为不区分大小写的匹配式。纯 ASCII 的关键词两端以单词字符开头或结尾时加上单词边界，
+// 并允许常见的英文词尾；其他关键词按子串匹配。
+func compileKeyword(keyword string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(keyword)
+	if isASCII(keyword) {
+		if isWordByte(keyword[0]) {
+			expr = `\b` + expr
+		}
+		if isWordByte(keyword[len(keyword)-1]) {
+			expr += `(?:s|es|d|ed|ing)?\b`
+		}
+	}
+	return regexp.MustCompile(`(?i)` + expr)
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// Categorize 根据提交信息和变更文件列表返回类别。
+// 先按顺序匹配关键词，全部未命中时再按顺序匹配路径，仍未命中则返回 Other。
+func (c *Categorizer) Categorize(message string, files []string) Category {
+	subject := strings.Split(message, "\n")[0]
+	for i, rule := range c.rules {
+		for _, keyword := range c.keywords[i] {
+			if keyword.MatchString(subject) {
+				return rule.Category
+			}
+		}
+	}
+	if len(files) == 0 {
+		return Other
+	}
+	for _, rule := range c.rules {
+		if len(rule.Paths) > 0 && allFilesMatch(files, rule.Paths) {
+			return rule.Category
+		}
+	}
+	return Other
+}
+
+func allFilesMatch(files, patterns []string) bool {
+	for _, file := range files {
+		if !matchAny(file, patterns) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(file string, patterns []string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case strings.HasSuffix(pattern, "/"):
+			if strings.HasPrefix(file, pattern) {
+				return true
+			}
+		case !strings.Contains(pattern, "/"):
+			if ok, _ := path.Match(pattern, path.Base(file)); ok {
+				return true
+			}
+		default:
+			if ok, _ := path.Match(pattern, file); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
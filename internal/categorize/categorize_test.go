@@ -0,0 +1,84 @@
+package categorize
+
+import "testing"
+
+func TestCategorize_Keywords(t *testing.T) {
+	c := New(nil)
+	tests := []struct {
+		message string
+		want    Category
+	}{
+		{"新增光计算概论的课程信息及教师评价 (#2)", Review},
+		{"增加Satellite对演讲与口才的评价", Review},
+		{"订正笔记：更改了笔记中的一些错误 (#4)", Correction},
+		{"添加学习建议 (#16)", Docs},
+		{"更新 readme.toml", Docs},
+		{"上传 2025 期末试卷", Material},
+		{"重命名实验目录", Restructure},
+		{"删除旧 toml", Trivial},
+		{"Merge branch 'main' into dev", Trivial},
+		{"随便写写", Other},
+		{"上传试卷\n\n顺便修复错误", Material}, // 只看首行
+		{"Fix typo in exam answers", Trivial},
+		{"Fixed wrong answer in chapter 3", Correction},
+		{"Added 2025 final exam", Material},
+		{"Update prefix of lab files", Other}, // fix 不匹配 prefix
+		{"Address review comments", Other},    // add 不匹配 address
+		{"Adjust padding in slides", Other},   // add 不匹配 padding
+		{"Uploading notes for ch2", Material},
+	}
+	for _, tt := range tests {
+		if got := c.Categorize(tt.message, nil); got != tt.want {
+			t.Errorf("Categorize(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestCategorize_Paths(t *testing.T) {
+	c := New(nil)
+	tests := []struct {
+		name  string
+		files []string
+		want  Category
+	}{
+		{"AllPDF", []string{"exams/2025.pdf", "notes/ch1.PDF.pdf"}, Material},
+		{"WorkflowOnly", []string{".github/workflows/ci.yml"}, Trivial},
+		{"Readme", []string{"README.md", "docs/intro.md"}, Docs},
+		{"Mixed", []string{"README.md", "exams/2025.pdf"}, Other},
+		{"NoFiles", nil, Other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Categorize("更新", tt.files); got != tt.want {
+				t.Errorf("Categorize(files=%v) = %q, want %q", tt.files, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_CustomRulesTakePrecedence(t *testing.T) {
+	c := New([]Rule{
+		{Category: Material, Keywords: []string{"教师评价"}},
+		{Category: Trivial, Paths: []string{"assets/"}},
+	})
+	if got := c.Categorize("新增教师评价", nil); got != Material {
+		t.Errorf("custom keyword rule should win, got %q", got)
+	}
+	if got := c.Categorize("更新", []string{"assets/logo.svg"}); got != Trivial {
+		t.Errorf("custom path rule should match, got %q", got)
+	}
+}
+
+func TestCategory_TitleAndValid(t *testing.T) {
+	if Material.Title() != "新增资料" || Trivial.Title() != "日常维护" {
+		t.Errorf("unexpected titles: %q %q", Material.Title(), Trivial.Title())
+	}
+	if Category("unknown").Valid() || Category("unknown").Title() != "其他更新" {
+		t.Errorf("unknown category should be invalid and fall back to 其他更新")
+	}
+	for _, c := range Order {
+		if !c.Valid() {
+			t.Errorf("category %q in Order is not valid", c)
+		}
+	}
+}
@@ -1,6 +1,385 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/bots"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/message"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/openai"
+	"gopkg.in/yaml.v3"
+)
+
 const (
 	OrgName      = "HITSZ-OpenAuto"
 	ReposListURL = "https://raw.githubusercontent.com/HITSZ-OpenAuto/repos-management/refs/heads/main/repos_list.txt"
+	DefaultPath  = "hoa-news.yaml" // 默认配置文件路径，相对于仓库根目录
+
+	defaultCacheDir     = "cache"
+	defaultDedupeWindow = 30 * time.Minute
+
+	LayoutDay        = "day"        // 按天分组的周报布局
+	LayoutCourse     = "course"     // 按课程分组的周报布局
+	LayoutCategory   = "category"   // 按类别分组的日报布局
+	LayoutDepartment = "department" // 先按院系、再按课程或类别分组，日报和周报均可使用
+
+	FeedContentFull    = "full"    // 订阅源条目包含完整的周报正文
+	FeedContentSummary = "summary" // 订阅源条目只包含 AI 摘要，没有摘要时使用 front matter 中的描述
+
+	DingTalkActionCard = "actionCard" // 钉钉 actionCard 消息，底部为跳转按钮
+	DingTalkMarkdown   = "markdown"   // 钉钉 markdown 消息
 )
+
+// Config 对应 hoa-news.yaml 的内容，所有字段均可省略，省略时使用默认值。
+type Config struct {
+	AliasesFile  string             `yaml:"aliases_file"`  // 贡献者别名文件路径（相对于配置文件所在目录），留空表示不做身份归并
+	CacheDir     string             `yaml:"cache_dir"`     // 跨运行持久化的缓存目录（相对于配置文件所在目录），默认为 cache
+	TemplatesDir string             `yaml:"templates_dir"` // 覆盖内置报告模板的目录（相对于配置文件所在目录），留空表示只使用内置模板
+	Languages    []i18n.Lang        `yaml:"languages"`     // 日报和周报的输出语言，默认只输出 zh；其他语言写到带语言后缀的同级文件，如 index.en.md
+	Contributors ContributorsConfig `yaml:"contributors"`
+	Categories   CategoriesConfig   `yaml:"categories"`
+	Weekly       WeeklyConfig       `yaml:"weekly"`
+	Dedupe       DedupeConfig       `yaml:"dedupe"`
+	Attribution  AttributionConfig  `yaml:"attribution"`
+	Messages     MessagesConfig     `yaml:"messages"`
+	Bots         bots.Config        `yaml:"bots"`
+	Daily        DailyConfig        `yaml:"daily"`
+	Departments  DepartmentsConfig  `yaml:"departments"`
+	Output       OutputConfig       `yaml:"output"`
+	Feed         FeedConfig         `yaml:"feed"`
+	Translation  TranslationConfig  `yaml:"translation"`
+	Deliver      DeliverConfig      `yaml:"deliver"`
+
+	Aliases []identity.Alias `yaml:"-"` // 由 Load 从 AliasesFile 读取
+}
+
+// ContributorsConfig 控制贡献者页面和排行榜的生成。
+type ContributorsConfig struct {
+	OptOut []string `yaml:"opt_out"` // 不希望公开展示的 GitHub 登录名，不生成个人页面，也不出现在排行榜中
+}
+
+// CategoriesConfig 控制 commit 分类。
+type CategoriesConfig struct {
+	FetchFiles bool              `yaml:"fetch_files"` // 是否为日报和周报中的每条提交拉取变更文件列表，开启后路径规则才会生效
+	Rules      []categorize.Rule `yaml:"rules"`       // 自定义分类规则，优先于内置规则
+}
+
+// WeeklyConfig 控制周报的布局。
+type WeeklyConfig struct {
+	Layout        string `yaml:"layout"`         // 周报正文布局：day（默认）或 course
+	SummaryLayout string `yaml:"summary_layout"` // 作为 AI 摘要输入的布局，默认 course，与摘要按课程归类的要求一致
+}
+
+// DailyConfig 控制日报的布局和 JSON Feed 输出。
+type DailyConfig struct {
+	Layout   string `yaml:"layout"`    // 日报正文布局：category（默认）或 department
+	FeedDays int    `yaml:"feed_days"` // news/daily.feed.json 中条目的保留天数，默认 7，设为 0 不生成
+}
+
+// DepartmentsConfig 控制按院系分组时课程所属院系的判定。
+// 优先使用 readme.toml 中的 department，缺失时按课程代码（或仓库名）的字母前缀查表。
+type DepartmentsConfig struct {
+	Prefixes map[string]string `yaml:"prefixes"` // 课程代码前缀 -> 院系名，不区分大小写，与内置映射合并；Load 之后键均为大写
+	Fallback string            `yaml:"fallback"` // 无法判定院系时使用的分组名，默认为「其他课程」
+}
+
+// DefaultDepartmentPrefixes 是内置的课程代码前缀到院系名的映射，键为大写。
+var DefaultDepartmentPrefixes = map[string]string{
+	"COMP":  "计算机",
+	"CS":    "计算机",
+	"EE":    "电气工程",
+	"AUTO":  "自动化",
+	"MECH":  "机械工程",
+	"MATH":  "数学",
+	"PHYS":  "物理",
+	"CROSS": "跨专业课程",
+}
+
+// DefaultDepartmentFallback 是未配置 departments.fallback 时使用的分组名。
+const DefaultDepartmentFallback = "其他课程"
+
+// OutputConfig 控制 Markdown 之外的额外输出。
+type OutputConfig struct {
+	JSON bool `yaml:"json"` // 是否在日报和周报旁写出同名的 JSON 文件（如 news/daily.json），结构见 schema/report.schema.json
+}
+
+// FeedConfig 控制周报归档的 RSS 和 Atom 订阅源，SiteURL 同时用于日报的 JSON Feed。
+type FeedConfig struct {
+	SiteURL     string `yaml:"site_url"`    // 网站地址，周报链接为 <site_url>/news/weekly/<目录名>/，默认 https://hoa.moe
+	Title       string `yaml:"title"`       // 订阅源标题
+	Description string `yaml:"description"` // 订阅源描述
+	Content     string `yaml:"content"`     // 条目内容：full 或 summary，默认 full
+	Limit       int    `yaml:"limit"`       // 最多收录的周报期数，按日期从新到旧，默认 20，设为 0 不限制
+}
+
+// TranslationConfig 控制英文周报中 AI 摘要和课程名的翻译，只在 languages 包含 en 时生效。
+type TranslationConfig struct {
+	Enabled      bool   `yaml:"enabled"`       // 是否调用 AI 翻译，关闭时英文周报不含摘要，课程名保留中文
+	GlossaryFile string `yaml:"glossary_file"` // 术语表路径（相对于配置文件所在目录），规定课程名和术语的译法，留空表示不使用术语表
+
+	Glossary openai.Glossary `yaml:"-"` // 由 Load 从 GlossaryFile 读取
+}
+
+// DeliverConfig 控制 deliver 命令将已生成的报告投递到站外渠道。
+type DeliverConfig struct {
+	Email      EmailConfig    `yaml:"email"`
+	MaxCourses int            `yaml:"max_courses"` // 群聊推送中最多列出的课程数，按提交数从多到少，默认 8
+	Feishu     FeishuConfig   `yaml:"feishu"`
+	DingTalk   DingTalkConfig `yaml:"dingtalk"`
+	QQ         QQConfig       `yaml:"qq"`
+	Telegram   TelegramConfig `yaml:"telegram"`
+	Discord    DiscordConfig  `yaml:"discord"`
+	Slack      SlackConfig    `yaml:"slack"`
+}
+
+// FeishuConfig 控制推送到飞书群自定义机器人。webhook 地址和签名密钥从环境变量 FEISHU_WEBHOOK、FEISHU_SECRET 读取，
+// 未设置密钥时不签名。
+type FeishuConfig struct {
+	Enabled bool `yaml:"enabled"` // 是否在 daily 和 weekly 之后推送消息卡片
+}
+
+// EmailConfig 控制通过 SMTP 发送报告邮件。SMTP 密码从环境变量 SMTP_PASSWORD 读取，不写入配置文件。
+type EmailConfig struct {
+	Host           string        `yaml:"host"`            // SMTP 服务器地址
+	Port           int           `yaml:"port"`            // SMTP 端口，默认 587
+	Username       string        `yaml:"username"`        // 登录用户名，留空表示不认证
+	From           string        `yaml:"from"`            // 发件人，如 AUTO 周报 <news@hoa.moe>
+	StartTLS       bool          `yaml:"starttls"`        // 是否要求 STARTTLS，默认开启，服务器不支持时放弃发送
+	RecipientsFile string        `yaml:"recipients_file"` // 收件人列表文件路径（相对于配置文件所在目录），每行一个地址，# 开头为注释
+	BatchSize      int           `yaml:"batch_size"`      // 每个 SMTP 连接发送的邮件数，默认 20
+	BatchInterval  time.Duration `yaml:"batch_interval"`  // 相邻两批之间的等待时间，用于限速，默认 10s
+	FailureLog     string        `yaml:"failure_log"`     // 记录发送失败收件人的 JSON 文件路径（相对于配置文件所在目录），默认为收件人列表所在目录下的 email-failures.json
+}
+
+// DingTalkConfig 控制推送到钉钉群自定义机器人。webhook 地址和加签密钥从环境变量 DINGTALK_WEBHOOK、DINGTALK_SECRET 读取，
+// 未设置密钥时不加签。
+type DingTalkConfig struct {
+	Enabled bool   `yaml:"enabled"`  // 是否在 daily 和 weekly 之后推送
+	MsgType string `yaml:"msg_type"` // 消息类型：actionCard（默认，底部为「查看全部」按钮）或 markdown（正文末尾附链接）
+}
+
+// QQConfig 控制通过 OneBot v11 HTTP API 向 QQ 群推送周报的纯文本摘要。
+// access token 从环境变量 ONEBOT_ACCESS_TOKEN 读取，未设置时不认证。
+type QQConfig struct {
+	Enabled   bool    `yaml:"enabled"`    // 是否在 weekly 之后推送
+	Endpoint  string  `yaml:"endpoint"`   // OneBot HTTP API 地址，如 http://127.0.0.1:3000
+	Groups    []int64 `yaml:"groups"`     // 目标群号
+	MaxLength int     `yaml:"max_length"` // 单条消息的最大字符数，超出时在课程之间拆成多条，默认 1500
+}
+
+// TelegramConfig 控制通过 Telegram Bot API 推送。bot token 从环境变量 TELEGRAM_BOT_TOKEN 读取。
+type TelegramConfig struct {
+	Enabled bool     `yaml:"enabled"`  // 是否在 daily 和 weekly 之后推送
+	ChatIDs []string `yaml:"chat_ids"` // 目标会话 ID 或公开频道名（如 @hoa_news）
+}
+
+// DiscordConfig 控制推送到 Discord 频道。webhook 地址从环境变量 DISCORD_WEBHOOK 读取。
+type DiscordConfig struct {
+	Enabled bool `yaml:"enabled"` // 是否在 daily 和 weekly 之后推送 embed 消息
+}
+
+// SlackConfig 控制推送到 Slack 频道。incoming webhook 地址从环境变量 SLACK_WEBHOOK 读取。
+type SlackConfig struct {
+	Enabled bool `yaml:"enabled"` // 是否在 daily 和 weekly 之后推送 Block Kit 消息
+}
+
+// DedupeConfig 控制批量提交的去重。
+type DedupeConfig struct {
+	Window time.Duration `yaml:"window"` // 同一作者相同提交信息的相邻提交间隔不超过该值时合并为一条，默认 30m，设为 0 关闭去重
+}
+
+// AttributionConfig 控制提交的归属。
+type AttributionConfig struct {
+	PullRequests bool `yaml:"pull_requests"` // 是否将 squash 合并和 merge commit 关联到 PR 并计入 PR 作者名下，默认开启
+}
+
+// MessagesConfig 控制提交信息的规范化和过滤。
+type MessagesConfig struct {
+	message.Config `yaml:",inline"`
+	DropLog        string `yaml:"drop_log"` // 记录被过滤提交及原因的 JSON 文件路径（相对于配置文件所在目录），留空表示只输出统计日志
+}
+
+// Load 读取并解析 path 指向的配置文件。文件不存在时返回默认配置。
+func Load(path string) (*Config, error) {
+	cfg := &Config{
+		Languages:   []i18n.Lang{i18n.Default},
+		Weekly:      WeeklyConfig{Layout: LayoutDay, SummaryLayout: LayoutCourse},
+		Daily:       DailyConfig{Layout: LayoutCategory, FeedDays: 7},
+		Departments: DepartmentsConfig{Fallback: DefaultDepartmentFallback},
+		Dedupe:      DedupeConfig{Window: defaultDedupeWindow},
+		Feed: FeedConfig{
+			SiteURL:     "https://hoa.moe",
+			Title:       "AUTO 周报",
+			Description: "HITSZ-OpenAuto 各课程仓库的每周更新",
+			Content:     FeedContentFull,
+			Limit:       20,
+		},
+		Attribution: AttributionConfig{PullRequests: true},
+		Deliver: DeliverConfig{
+			Email: EmailConfig{
+				Port:          587,
+				StartTLS:      true,
+				BatchSize:     20,
+				BatchInterval: 10 * time.Second,
+			},
+			MaxCourses: 8,
+			DingTalk:   DingTalkConfig{MsgType: DingTalkActionCard},
+			QQ:         QQConfig{MaxLength: 1500},
+		},
+		Bots: bots.Config{
+			Automation: bots.AutomationConfig{MinRepos: 10, Window: 30 * time.Second},
+			ShowCount:  true,
+		},
+		Messages: MessagesConfig{Config: message.Config{
+			Strip:     []string{message.StripConventional, message.StripTags, message.StripEmoji},
+			Languages: []string{"zh", "en"},
+			MinRatio:  0.5,
+		}},
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			cfg.CacheDir = resolvePath(path, defaultCacheDir)
+			cfg.Departments.Prefixes, _ = mergeDepartmentPrefixes(nil)
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config %q: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %q: %w", path, err)
+	}
+	for _, layout := range []string{cfg.Weekly.Layout, cfg.Weekly.SummaryLayout} {
+		if layout != LayoutDay && layout != LayoutCourse && layout != LayoutDepartment {
+			return nil, fmt.Errorf("invalid config %q: unknown weekly layout %q", path, layout)
+		}
+	}
+	if layout := cfg.Daily.Layout; layout != LayoutCategory && layout != LayoutDepartment {
+		return nil, fmt.Errorf("invalid config %q: unknown daily layout %q", path, layout)
+	}
+	if cfg.Daily.FeedDays < 0 {
+		return nil, fmt.Errorf("invalid config %q: daily.feed_days must not be negative", path)
+	}
+	if content := cfg.Feed.Content; content != FeedContentFull && content != FeedContentSummary {
+		return nil, fmt.Errorf("invalid config %q: unknown feed content %q", path, content)
+	}
+	if cfg.Feed.Limit < 0 {
+		return nil, fmt.Errorf("invalid config %q: feed.limit must not be negative", path)
+	}
+	if len(cfg.Languages) == 0 {
+		return nil, fmt.Errorf("invalid config %q: languages must not be empty", path)
+	}
+	for i, lang := range cfg.Languages {
+		if cfg.Languages[i], err = i18n.Parse(string(lang)); err != nil {
+			return nil, fmt.Errorf("invalid config %q: languages: %w", path, err)
+		}
+	}
+	if email := cfg.Deliver.Email; email.Port <= 0 || email.Port > 65535 {
+		return nil, fmt.Errorf("invalid config %q: deliver.email.port %d is out of range", path, email.Port)
+	}
+	if cfg.Deliver.Email.BatchSize <= 0 {
+		return nil, fmt.Errorf("invalid config %q: deliver.email.batch_size must be positive", path)
+	}
+	if cfg.Deliver.Email.BatchInterval < 0 {
+		return nil, fmt.Errorf("invalid config %q: deliver.email.batch_interval must not be negative", path)
+	}
+	if cfg.Deliver.MaxCourses <= 0 {
+		return nil, fmt.Errorf("invalid config %q: deliver.max_courses must be positive", path)
+	}
+	if msgType := cfg.Deliver.DingTalk.MsgType; msgType != DingTalkActionCard && msgType != DingTalkMarkdown {
+		return nil, fmt.Errorf("invalid config %q: unknown deliver.dingtalk.msg_type %q", path, msgType)
+	}
+	if qq := cfg.Deliver.QQ; qq.Enabled && (qq.Endpoint == "" || len(qq.Groups) == 0) {
+		return nil, fmt.Errorf("invalid config %q: deliver.qq requires endpoint and groups", path)
+	}
+	if cfg.Deliver.QQ.MaxLength < 100 {
+		return nil, fmt.Errorf("invalid config %q: deliver.qq.max_length must be at least 100", path)
+	}
+	if telegram := cfg.Deliver.Telegram; telegram.Enabled && len(telegram.ChatIDs) == 0 {
+		return nil, fmt.Errorf("invalid config %q: deliver.telegram requires chat_ids", path)
+	}
+	if from := cfg.Deliver.Email.From; from != "" {
+		if _, err := mail.ParseAddress(from); err != nil {
+			return nil, fmt.Errorf("invalid config %q: deliver.email.from: %w", path, err)
+		}
+	}
+	if cfg.Departments.Fallback == "" {
+		cfg.Departments.Fallback = DefaultDepartmentFallback
+	}
+	if cfg.Departments.Prefixes, err = mergeDepartmentPrefixes(cfg.Departments.Prefixes); err != nil {
+		return nil, fmt.Errorf("invalid config %q: %w", path, err)
+	}
+	for i, rule := range cfg.Categories.Rules {
+		if !rule.Category.Valid() {
+			return nil, fmt.Errorf("invalid config %q: categories.rules[%d] has unknown category %q", path, i, rule.Category)
+		}
+	}
+	if _, err := message.New(cfg.Messages.Config); err != nil {
+		return nil, fmt.Errorf("invalid config %q: messages: %w", path, err)
+	}
+	if _, err := bots.New(cfg.Bots); err != nil {
+		return nil, fmt.Errorf("invalid config %q: bots: %w", path, err)
+	}
+	if cfg.Messages.DropLog != "" {
+		cfg.Messages.DropLog = resolvePath(path, cfg.Messages.DropLog)
+	}
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = defaultCacheDir
+	}
+	cfg.CacheDir = resolvePath(path, cfg.CacheDir)
+	if cfg.TemplatesDir != "" {
+		cfg.TemplatesDir = resolvePath(path, cfg.TemplatesDir)
+	}
+	if cfg.Deliver.Email.RecipientsFile != "" {
+		cfg.Deliver.Email.RecipientsFile = resolvePath(path, cfg.Deliver.Email.RecipientsFile)
+	}
+	if cfg.Deliver.Email.FailureLog != "" {
+		cfg.Deliver.Email.FailureLog = resolvePath(path, cfg.Deliver.Email.FailureLog)
+	}
+	if cfg.Translation.GlossaryFile != "" {
+		if cfg.Translation.Glossary, err = openai.LoadGlossary(resolvePath(path, cfg.Translation.GlossaryFile)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.AliasesFile != "" {
+		if cfg.Aliases, err = identity.LoadAliases(resolvePath(path, cfg.AliasesFile)); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// mergeDepartmentPrefixes 将配置的前缀统一为大写并覆盖到内置映射的副本上。
+// 配置中只有大小写不同的重复前缀无法确定取哪个，返回错误。
+func mergeDepartmentPrefixes(configured map[string]string) (map[string]string, error) {
+	prefixes := make(map[string]string, len(DefaultDepartmentPrefixes)+len(configured))
+	for prefix, name := range DefaultDepartmentPrefixes {
+		prefixes[prefix] = name
+	}
+	seen := make(map[string]string, len(configured))
+	for prefix, name := range configured {
+		key := strings.ToUpper(prefix)
+		if other, ok := seen[key]; ok {
+			return nil, fmt.Errorf("departments.prefixes has duplicate prefix %q and %q", other, prefix)
+		}
+		seen[key] = prefix
+		prefixes[key] = name
+	}
+	return prefixes, nil
+}
+
+// resolvePath 将配置中引用的相对路径解析为相对于配置文件所在目录的路径。
+func resolvePath(configPath, ref string) string {
+	if filepath.IsAbs(ref) {
+		return ref
+	}
+	return filepath.Join(filepath.Dir(configPath), ref)
+}
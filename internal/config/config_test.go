@@ -0,0 +1,372 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+)
+
+func TestLoad_MissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Contributors.OptOut) != 0 {
+		t.Errorf("expected empty opt-out list, got %v", cfg.Contributors.OptOut)
+	}
+}
+
+func TestLoad_ParsesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	content := "contributors:\n  opt_out:\n    - alice\n    - bob\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if got := cfg.Contributors.OptOut; len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Errorf("OptOut = %v, want [alice bob]", got)
+	}
+}
+
+func TestLoad_InvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("contributors: [\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatalf("expected error for invalid YAML")
+	}
+}
+
+func TestLoad_ReadsAliasesFile(t *testing.T) {
+	dir := t.TempDir()
+	aliases := filepath.Join(dir, "aliases.yaml")
+	if err := os.WriteFile(aliases, []byte("- name: 张三\n  login: zhangsan\n"), 0o644); err != nil {
+		t.Fatalf("failed to write aliases: %v", err)
+	}
+	path := filepath.Join(dir, "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("aliases_file: aliases.yaml\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Aliases) != 1 || cfg.Aliases[0].Login != "zhangsan" {
+		t.Errorf("Aliases = %+v, want one alias for zhangsan", cfg.Aliases)
+	}
+}
+
+func TestLoad_CacheDirDefaultsNextToConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Load(filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if want := filepath.Join(dir, "cache"); cfg.CacheDir != want {
+		t.Errorf("CacheDir = %q, want %q", cfg.CacheDir, want)
+	}
+
+	path := filepath.Join(dir, "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("cache_dir: .state\ntemplates_dir: templates\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if want := filepath.Join(dir, ".state"); cfg.CacheDir != want {
+		t.Errorf("CacheDir = %q, want %q", cfg.CacheDir, want)
+	}
+	if want := filepath.Join(dir, "templates"); cfg.TemplatesDir != want {
+		t.Errorf("TemplatesDir = %q, want %q", cfg.TemplatesDir, want)
+	}
+}
+
+func TestLoad_RejectsUnknownCategory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	content := "categories:\n  rules:\n    - category: nonsense\n      keywords: [x]\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatalf("expected error for unknown category")
+	}
+}
+
+func TestLoad_WeeklyLayout(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Weekly.Layout != LayoutDay || cfg.Weekly.SummaryLayout != LayoutCourse {
+		t.Errorf("Weekly = %+v, want day layout with course summary input", cfg.Weekly)
+	}
+
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("weekly:\n  layout: course\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if cfg, err = Load(path); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Weekly.Layout != LayoutCourse || cfg.Weekly.SummaryLayout != LayoutCourse {
+		t.Errorf("Weekly = %+v, want course layout", cfg.Weekly)
+	}
+
+	if err := os.WriteFile(path, []byte("weekly:\n  layout: month\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatalf("expected error for unknown layout")
+	}
+}
+
+func TestLoad_DedupeWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("weekly:\n  layout: day\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Dedupe.Window != 30*time.Minute {
+		t.Errorf("Dedupe.Window = %v, want default 30m", cfg.Dedupe.Window)
+	}
+
+	if err := os.WriteFile(path, []byte("dedupe:\n  window: 2h\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if cfg, err = Load(path); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Dedupe.Window != 2*time.Hour {
+		t.Errorf("Dedupe.Window = %v, want 2h", cfg.Dedupe.Window)
+	}
+}
+
+func TestLoad_ValidatesMessageRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("messages:\n  deny: [\"(\"]\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatalf("expected error for invalid deny pattern")
+	}
+
+	if err := os.WriteFile(path, []byte("messages:\n  languages: [zh]\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Messages.Languages) != 1 || cfg.Messages.MinRatio != 0.5 || len(cfg.Messages.Strip) != 3 {
+		t.Errorf("Messages = %+v, want languages overridden and other defaults kept", cfg.Messages)
+	}
+}
+
+func TestLoad_Departments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	content := "daily:\n  layout: department\ndepartments:\n  prefixes:\n    ee: 电子与信息工程\n    Aero: 航天\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Daily.Layout != LayoutDepartment {
+		t.Errorf("Daily.Layout = %q, want %q", cfg.Daily.Layout, LayoutDepartment)
+	}
+	prefixes := cfg.Departments.Prefixes
+	if prefixes["EE"] != "电子与信息工程" || prefixes["AERO"] != "航天" || prefixes["COMP"] != "计算机" || len(prefixes) != len(DefaultDepartmentPrefixes)+1 {
+		t.Errorf("expected configured prefixes uppercased and merged with built-ins, got %v", prefixes)
+	}
+	if DefaultDepartmentPrefixes["EE"] != "电气工程" {
+		t.Errorf("built-in prefixes must not be modified by config")
+	}
+	if cfg.Departments.Fallback != DefaultDepartmentFallback {
+		t.Errorf("Fallback = %q, want %q", cfg.Departments.Fallback, DefaultDepartmentFallback)
+	}
+
+	if err := os.WriteFile(path, []byte("departments:\n  prefixes:\n    EE: 电子\n    ee: 电气\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Errorf("expected error for prefixes differing only in case")
+	}
+
+	if err := os.WriteFile(path, []byte("daily:\n  layout: day\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Errorf("expected error for unknown daily layout")
+	}
+}
+
+func TestLoad_OutputJSON(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Output.JSON {
+		t.Errorf("Output.JSON should default to false")
+	}
+
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("output:\n  json: true\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if cfg, err = Load(path); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.Output.JSON {
+		t.Errorf("Output.JSON = false, want true")
+	}
+}
+
+func TestLoad_Feed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("feed:\n  content: summary\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Feed.Content != FeedContentSummary || cfg.Feed.SiteURL != "https://hoa.moe" || cfg.Feed.Limit != 20 {
+		t.Errorf("Feed = %+v, want summary content with other defaults kept", cfg.Feed)
+	}
+
+	for _, content := range []string{"feed:\n  content: excerpt\n", "feed:\n  limit: -1\n"} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
+
+func TestLoad_DailyFeedDays(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Daily.FeedDays != 7 {
+		t.Errorf("Daily.FeedDays = %d, want default 7", cfg.Daily.FeedDays)
+	}
+
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("daily:\n  feed_days: -1\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Errorf("expected error for negative feed_days")
+	}
+}
+
+func TestLoad_Languages(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Languages) != 1 || cfg.Languages[0] != i18n.ZH {
+		t.Errorf("Languages = %v, want default [zh]", cfg.Languages)
+	}
+
+	path := filepath.Join(t.TempDir(), "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("languages: [zh, EN]\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if cfg, err = Load(path); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Languages) != 2 || cfg.Languages[1] != i18n.EN {
+		t.Errorf("Languages = %v, want [zh en]", cfg.Languages)
+	}
+
+	for _, content := range []string{"languages: [fr]\n", "languages: []\n"} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
+
+func TestLoad_TranslationGlossary(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "glossary.yaml"), []byte("电路原理: Principles of Electric Circuits\n"), 0o644); err != nil {
+		t.Fatalf("failed to write glossary: %v", err)
+	}
+	path := filepath.Join(dir, "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("translation:\n  enabled: true\n  glossary_file: glossary.yaml\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.Translation.Enabled || cfg.Translation.Glossary["电路原理"] != "Principles of Electric Circuits" {
+		t.Errorf("Translation = %+v", cfg.Translation)
+	}
+
+	if err := os.WriteFile(path, []byte("translation:\n  glossary_file: missing.yaml\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Errorf("expected error for missing glossary file")
+	}
+}
+
+func TestLoad_Deliver(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hoa-news.yaml")
+	if err := os.WriteFile(path, []byte("deliver:\n  email:\n    host: smtp.example.com\n    from: AUTO 周报 <news@hoa.moe>\n    recipients_file: recipients.txt\n    batch_size: 5\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	email := cfg.Deliver.Email
+	if email.Port != 587 || !email.StartTLS || email.BatchSize != 5 || email.BatchInterval != 10*time.Second {
+		t.Errorf("Email = %+v, want defaults for omitted fields", email)
+	}
+	if cfg.Deliver.MaxCourses != 8 || cfg.Deliver.Feishu.Enabled || cfg.Deliver.DingTalk.MsgType != DingTalkActionCard {
+		t.Errorf("Deliver = %+v, want notifications disabled by default", cfg.Deliver)
+	}
+	if email.RecipientsFile != filepath.Join(dir, "recipients.txt") {
+		t.Errorf("RecipientsFile = %q, want it resolved against the config directory", email.RecipientsFile)
+	}
+
+	for _, content := range []string{
+		"deliver:\n  email:\n    port: 70000\n",
+		"deliver:\n  email:\n    batch_size: 0\n",
+		"deliver:\n  email:\n    from: not an address\n",
+		"deliver:\n  max_courses: 0\n",
+		"deliver:\n  dingtalk:\n    msg_type: text\n",
+		"deliver:\n  qq:\n    enabled: true\n    endpoint: http://127.0.0.1:3000\n",
+		"deliver:\n  telegram:\n    enabled: true\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package deliver
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+const maxCourseMessages = 3 // 群聊推送中每门课程最多列出的提交信息条数
+
+// Digest 是群聊推送使用的报告摘要，由报告的 Markdown 和 JSON 输出整理而来，文本均未转义。
+type Digest struct {
+	Kind      string
+	Title     string
+	URL       string // 完整报告的地址
+	Summary   string // 周报的 AI 摘要（Markdown），日报为空
+	Commits   int
+	Courses   []DigestCourse // 按提交数从多到少排列
+	NewIssues int            // 报告时间窗口内新建的 issue 数，只统计日报
+	NewPRs    int
+	IssuesURL string // 与 NewIssues 对应的 GitHub 搜索页，日报限定为时间窗口内新建的 issue
+	PRsURL    string
+}
+
+// DigestCourse 是一门课程在报告中的更新。
+type DigestCourse struct {
+	Repo     string
+	Name     string // 课程名，缺失时为仓库名
+	URL      string
+	Commits  int
+	Messages []string // 去重后的提交信息，按时间从新到旧
+}
+
+// newDigest 由已生成的报告整理推送摘要。报告没有 JSON 输出时只包含标题、摘要和链接。
+func newDigest(p report.Published, orgName string) Digest {
+	d := Digest{
+		Kind:      p.Kind,
+		Title:     p.Title,
+		URL:       p.URL,
+		Summary:   p.Summary,
+		IssuesURL: searchURL("issues", "issue", orgName, time.Time{}),
+		PRsURL:    searchURL("pulls", "pr", orgName, time.Time{}),
+	}
+	doc := p.Document
+	if doc == nil {
+		return d
+	}
+
+	d.Commits = len(doc.Commits)
+	index := make(map[string]int)
+	seen := make(map[string]map[string]struct{})
+	for _, commit := range doc.Commits { // 已按时间降序
+		i, ok := index[commit.Repo]
+		if !ok {
+			name := commit.Repo
+			if meta, ok := doc.Courses[commit.Repo]; ok && meta.Name != "" {
+				name = meta.Name
+			}
+			i = len(d.Courses)
+			index[commit.Repo] = i
+			seen[commit.Repo] = make(map[string]struct{})
+			d.Courses = append(d.Courses, DigestCourse{Repo: commit.Repo, Name: name, URL: "https://github.com/" + orgName + "/" + commit.Repo})
+		}
+		course := &d.Courses[i]
+		course.Commits++
+		if _, dup := seen[commit.Repo][commit.Message]; !dup {
+			seen[commit.Repo][commit.Message] = struct{}{}
+			course.Messages = append(course.Messages, commit.Message)
+		}
+	}
+	sort.SliceStable(d.Courses, func(i, j int) bool {
+		if d.Courses[i].Commits != d.Courses[j].Commits {
+			return d.Courses[i].Commits > d.Courses[j].Commits
+		}
+		return d.Courses[i].Name < d.Courses[j].Name
+	})
+
+	if p.Kind == report.ReportKindDaily {
+		d.NewIssues = countCreatedSince(doc.Issues, doc.Window.Start)
+		d.NewPRs = countCreatedSince(doc.PullRequests, doc.Window.Start)
+		d.IssuesURL = searchURL("issues", "issue", orgName, doc.Window.Start)
+		d.PRsURL = searchURL("pulls", "pr", orgName, doc.Window.Start)
+	}
+	return d
+}
+
+// searchURL 返回组织内未关闭的 issue 或 PR 的 GitHub 搜索页，since 非零时只搜索此后创建的条目，
+// 与 countCreatedSince 的统计范围一致。
+func searchURL(page, kind, orgName string, since time.Time) string {
+	query := "is:open is:" + kind + " org:" + orgName
+	if !since.IsZero() {
+		query += " created:>=" + since.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return "https://github.com/" + page + "?q=" + url.QueryEscape(query)
+}
+
+// countCreatedSince 统计 start 之后创建的条目数，创建时间无法解析的条目不计入。
+func countCreatedSince(items []report.ReportItem, start time.Time) int {
+	n := 0
+	for _, item := range items {
+		if created, err := time.Parse(time.RFC3339, item.CreatedAt); err == nil && !created.Before(start) {
+			n++
+		}
+	}
+	return n
+}
+
+// limitCourses 返回前 max 门课程以及被省略的课程数。
+func limitCourses(courses []DigestCourse, max int) ([]DigestCourse, int) {
+	if len(courses) <= max {
+		return courses, 0
+	}
+	return courses[:max], len(courses) - max
+}
+
+// courseMarkdown 返回一门课程的 Markdown 段落：课程链接、提交数和最多 maxCourseMessages 条提交信息。
+func courseMarkdown(course DigestCourse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** · %d 条提交", utils.RenderSafeMarkdownLink(course.Name, course.URL), course.Commits)
+	messages := course.Messages
+	if len(messages) > maxCourseMessages {
+		messages = messages[:maxCourseMessages]
+	}
+	for _, message := range messages {
+		b.WriteString("\n- " + utils.SanitizeInlineText(message))
+	}
+	if more := len(course.Messages) - len(messages); more > 0 {
+		fmt.Fprintf(&b, "\n- …另有 %d 条", more)
+	}
+	return b.String()
+}
@@ -0,0 +1,141 @@
+// 钉钉群自定义机器人：以 markdown 或 actionCard 消息推送报告摘要，支持加签
+package deliver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// dingtalkMaxText 是消息正文的最大字符数。钉钉限制约 5000 字符，超出时消息发送失败，这里留出余量。
+const dingtalkMaxText = 4500
+
+// dingtalk 通过自定义机器人 webhook 发送消息，见 https://open.dingtalk.com/document/orgapp/custom-robot-access
+type dingtalk struct {
+	webhook    string
+	secret     string // 加签密钥，为空时不签名
+	msgType    string // config.DingTalkMarkdown 或 config.DingTalkActionCard
+	maxCourses int
+	client     *http.Client
+	now        func() time.Time
+}
+
+func newDingTalk(webhook, secret, msgType string, maxCourses int) *dingtalk {
+	return &dingtalk{webhook: webhook, secret: secret, msgType: msgType, maxCourses: maxCourses, client: &http.Client{Timeout: httpTimeout}, now: time.Now}
+}
+
+func (t *dingtalk) name() string { return "dingtalk" }
+
+type dingtalkMessage struct {
+	MsgType    string              `json:"msgtype"`
+	Markdown   *dingtalkMarkdown   `json:"markdown,omitempty"`
+	ActionCard *dingtalkActionCard `json:"actionCard,omitempty"`
+}
+
+type dingtalkMarkdown struct {
+	Title string `json:"title"` // 会话列表中显示的标题
+	Text  string `json:"text"`
+}
+
+type dingtalkActionCard struct {
+	Title       string `json:"title"`
+	Text        string `json:"text"`
+	SingleTitle string `json:"singleTitle"`
+	SingleURL   string `json:"singleURL"`
+}
+
+// dingtalkResponse 是 webhook 的响应，ErrCode 非 0 表示失败（如签名不匹配、超过每分钟 20 条的频率限制）。
+type dingtalkResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+func (t *dingtalk) send(d Digest) error {
+	target := t.webhook
+	if t.secret != "" {
+		sep := "&"
+		if !strings.Contains(target, "?") {
+			sep = "?"
+		}
+		timestamp := t.now().UnixMilli()
+		target += sep + "timestamp=" + strconv.FormatInt(timestamp, 10) + "&sign=" + url.QueryEscape(dingtalkSign(t.secret, timestamp))
+	}
+	var resp dingtalkResponse
+	if err := postJSON(t.client, target, buildDingTalkMessage(d, t.msgType, t.maxCourses), &resp); err != nil {
+		return err
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("dingtalk returned errcode %d: %s", resp.ErrCode, resp.ErrMsg)
+	}
+	return nil
+}
+
+// dingtalkSign 计算加签：以密钥对 "timestamp\nsecret" 做 HMAC-SHA256，再 Base64 编码，timestamp 为毫秒。
+func dingtalkSign(secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// buildDingTalkMessage 构建消息。actionCard 底部为「查看全部」按钮；markdown 消息在正文末尾附上同名链接。
+func buildDingTalkMessage(d Digest, msgType string, maxCourses int) dingtalkMessage {
+	if msgType == config.DingTalkActionCard {
+		return dingtalkMessage{MsgType: "actionCard", ActionCard: &dingtalkActionCard{
+			Title:       d.Title,
+			Text:        dingtalkText(d, maxCourses, dingtalkMaxText),
+			SingleTitle: "查看全部",
+			SingleURL:   d.URL,
+		}}
+	}
+	link := "\n\n" + utils.RenderSafeMarkdownLink("查看全部", d.URL)
+	text := dingtalkText(d, maxCourses, dingtalkMaxText-len([]rune(link)))
+	return dingtalkMessage{MsgType: "markdown", Markdown: &dingtalkMarkdown{Title: d.Title, Text: text + link}}
+}
+
+// dingtalkText 返回不超过 limit 个字符的 Markdown 正文。超出时在段落（摘要、每门课程）边界截断并注明，
+// 第一个段落就放不下时按字符截断。
+func dingtalkText(d Digest, maxCourses, limit int) string {
+	sections := []string{"### " + utils.SanitizeInlineText(d.Title)}
+	if d.Summary != "" {
+		sections = append(sections, d.Summary)
+	}
+	if d.Kind == report.ReportKindDaily {
+		sections = append(sections, dailyStats(d))
+	}
+	courses, omitted := limitCourses(d.Courses, maxCourses)
+	for _, course := range courses {
+		sections = append(sections, courseMarkdown(course))
+	}
+	if omitted > 0 {
+		sections = append(sections, fmt.Sprintf("另有 %d 门课程有更新。", omitted))
+	}
+
+	const truncated = "\n\n……内容过长，已截断"
+	full := strings.Join(sections, "\n\n")
+	if len([]rune(full)) <= limit {
+		return full
+	}
+	limit -= len([]rune(truncated))
+	text := sections[0]
+	for _, section := range sections[1:] {
+		next := text + "\n\n" + section
+		if len([]rune(next)) > limit {
+			break
+		}
+		text = next
+	}
+	if text == sections[0] && len(sections) > 1 { // 第一个段落（通常是摘要）就放不下时保留其开头部分
+		text = truncateRunes(text+"\n\n"+sections[1], limit)
+	}
+	return text + truncated
+}
@@ -0,0 +1,72 @@
+package deliver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+)
+
+func TestDingTalk_Send(t *testing.T) {
+	var got dingtalkMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("access_token") != "abc" || q.Get("timestamp") != "1770710400000" || q.Get("sign") != dingtalkSign("SEC123", 1770710400000) {
+			fmt.Fprint(w, `{"errcode":310000,"errmsg":"sign not match"}`)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		fmt.Fprint(w, `{"errcode":0,"errmsg":"ok"}`)
+	}))
+	defer server.Close()
+
+	dt := newDingTalk(server.URL+"/robot/send?access_token=abc", "SEC123", config.DingTalkActionCard, 8)
+	dt.now = func() time.Time { return time.UnixMilli(1770710400000) }
+	if err := dt.send(newDigest(testDailyPublished(), "HITSZ-OpenAuto")); err != nil {
+		t.Fatalf("send() returned error: %v", err)
+	}
+	if got.MsgType != "actionCard" || got.ActionCard == nil || got.ActionCard.SingleTitle != "查看全部" || got.ActionCard.SingleURL != "https://hoa.moe/news/daily/" {
+		t.Fatalf("message = %+v", got)
+	}
+	if !strings.Contains(got.ActionCard.Text, "提交 4 条，新 Issue 1 个，新 PR 1 个") || !strings.Contains(got.ActionCard.Text, "[电路原理](https://github.com/HITSZ-OpenAuto/EE3001)") {
+		t.Errorf("text = %q", got.ActionCard.Text)
+	}
+
+	dt.secret = "wrong"
+	if err := dt.send(newDigest(testDailyPublished(), "HITSZ-OpenAuto")); err == nil || !strings.Contains(err.Error(), "310000") {
+		t.Errorf("expected error for rejected signature, got %v", err)
+	}
+}
+
+func TestBuildDingTalkMessage_Truncates(t *testing.T) {
+	d := Digest{Kind: "weekly", Title: "AUTO 周报", URL: "https://hoa.moe/news/weekly/weekly-2026-02-13/", Summary: "- 本周摘要"}
+	for i := 0; i < 60; i++ {
+		d.Courses = append(d.Courses, DigestCourse{Name: fmt.Sprintf("课程 %d", i), URL: "https://github.com/HITSZ-OpenAuto/C", Commits: 1, Messages: []string{strings.Repeat("很长的提交信息", 20)}})
+	}
+
+	msg := buildDingTalkMessage(d, config.DingTalkMarkdown, 60)
+	text := msg.Markdown.Text
+	if n := len([]rune(text)); n > dingtalkMaxText {
+		t.Errorf("text has %d characters, want at most %d", n, dingtalkMaxText)
+	}
+	if !strings.HasSuffix(text, "……内容过长，已截断\n\n[查看全部](https://hoa.moe/news/weekly/weekly-2026-02-13/)") {
+		t.Errorf("truncated text should end with a note and the link:\n%s", text[len(text)-200:])
+	}
+	if !strings.Contains(text, "- 本周摘要") || strings.Contains(text, "课程 59") {
+		t.Errorf("truncation should keep the summary and drop trailing courses")
+	}
+
+	// 摘要本身超长时按字符截断
+	d.Summary = strings.Repeat("长", dingtalkMaxText*2)
+	d.Courses = nil
+	if text := buildDingTalkMessage(d, config.DingTalkActionCard, 8).ActionCard.Text; len([]rune(text)) > dingtalkMaxText || !strings.HasPrefix(text, "### AUTO 周报\n\n长长") {
+		t.Errorf("oversized summary should be cut to the limit, got %d characters", len([]rune(text)))
+	}
+}
@@ -0,0 +1,117 @@
+// Discord 推送：通过频道 webhook 以 embed 发送报告摘要
+package deliver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+// Discord embed 的长度限制，见 https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	discordMaxTitle       = 256
+	discordMaxDescription = 4096
+	discordMaxFields      = 25
+	discordMaxFieldName   = 256
+	discordMaxFieldValue  = 1024
+	discordMaxTotal       = 6000 // 标题、描述、字段名和值的总字符数
+)
+
+const (
+	discordColorDaily  = 0x2f81f7
+	discordColorWeekly = 0x3fb950
+)
+
+// discord 调用频道 webhook，见 https://discord.com/developers/docs/resources/webhook#execute-webhook
+type discord struct {
+	webhook    string
+	maxCourses int
+	client     *http.Client
+}
+
+func newDiscord(webhook string, maxCourses int) *discord {
+	return &discord{webhook: webhook, maxCourses: maxCourses, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (c *discord) name() string { return "discord" }
+
+type discordMessage struct {
+	Embeds          []discordEmbed         `json:"embeds"`
+	AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+}
+
+// discordAllowedMentions 为空时提交信息中的 @everyone 等不会触发提醒。
+type discordAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// send 发送一条带 embed 的消息，webhook 成功时返回 204。
+func (c *discord) send(d Digest) error {
+	return postJSON(c.client, c.webhook, discordMessage{Embeds: []discordEmbed{buildDiscordEmbed(d, c.maxCourses)}, AllowedMentions: discordAllowedMentions{Parse: []string{}}}, nil)
+}
+
+// buildDiscordEmbed 构建 embed：标题链接到完整报告，描述为摘要或日报统计，每门课程一个字段。
+// 字段按顺序加入，超出总长度限制时省略其余课程。
+func buildDiscordEmbed(d Digest, maxCourses int) discordEmbed {
+	m := discordMarkup
+	embed := discordEmbed{Title: truncateRunes(cleanText(d.Title), discordMaxTitle), Color: discordColorDaily}
+	if d.Kind == report.ReportKindWeekly {
+		embed.Color = discordColorWeekly
+	}
+	if url, ok := linkURL(d.URL); ok {
+		embed.URL = url
+	}
+	var description []string
+	if d.Summary != "" {
+		description = append(description, renderMarkdown(d.Summary, m))
+	}
+	if d.Kind == report.ReportKindDaily {
+		description = append(description, m.text(dailyStats(d)))
+	}
+	embed.Description = truncateRunes(strings.Join(description, "\n\n"), discordMaxDescription)
+
+	total := len([]rune(embed.Title)) + len([]rune(embed.Description))
+	courses, omitted := limitCourses(d.Courses, min(maxCourses, discordMaxFields-1)) // 留一个字段说明省略的课程
+	for i, course := range courses {
+		field := discordField{
+			Name:  truncateRunes(fmt.Sprintf("%s · %d 条提交", cleanText(course.Name), course.Commits), discordMaxFieldName),
+			Value: truncateRunes(discordCourseValue(course), discordMaxFieldValue),
+		}
+		size := len([]rune(field.Name)) + len([]rune(field.Value))
+		if total+size > discordMaxTotal-100 { // 留出省略说明的长度
+			omitted += len(courses) - i
+			break
+		}
+		total += size
+		embed.Fields = append(embed.Fields, field)
+	}
+	if omitted > 0 {
+		embed.Fields = append(embed.Fields, discordField{Name: "…", Value: m.text(fmt.Sprintf("另有 %d 门课程有更新，详见完整报告。", omitted))})
+	}
+	return embed
+}
+
+// discordCourseValue 返回课程字段的内容：提交信息和仓库链接。字段名中不能使用链接。
+func discordCourseValue(course DigestCourse) string {
+	lines := courseMessages(course, discordMarkup)
+	if url, ok := linkURL(course.URL); ok {
+		lines = append(lines, discordMarkup.link("查看仓库", url))
+	}
+	return strings.Join(lines, "\n")
+}
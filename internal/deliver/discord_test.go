@@ -0,0 +1,62 @@
+package deliver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDiscord_Send(t *testing.T) {
+	var msg discordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := newDiscord(server.URL+"/api/webhooks/1/secret", 2).send(testWeeklyDigest(3)); err != nil {
+		t.Fatalf("send() returned error: %v", err)
+	}
+	if len(msg.Embeds) != 1 || msg.AllowedMentions.Parse == nil || len(msg.AllowedMentions.Parse) != 0 {
+		t.Fatalf("message = %+v, want one embed with mentions disabled", msg)
+	}
+	embed := msg.Embeds[0]
+	if embed.URL != "https://hoa.moe/news/weekly/weekly-2026-02-13/" || embed.Color != discordColorWeekly {
+		t.Errorf("embed = %+v", embed)
+	}
+	if !strings.Contains(embed.Description, "**[电路原理](https://github.com/HITSZ-OpenAuto/EE3001)**") {
+		t.Errorf("description = %q", embed.Description)
+	}
+	if len(embed.Fields) != 3 || embed.Fields[0].Name != "课程00 · 2 条提交" || !strings.Contains(embed.Fields[2].Value, "另有 1 门课程") {
+		t.Errorf("fields = %+v, want 2 courses and a note for the omitted one", embed.Fields)
+	}
+}
+
+func TestBuildDiscordEmbed_Limits(t *testing.T) {
+	d := testWeeklyDigest(40)
+	for i := range d.Courses {
+		d.Courses[i].Messages = []string{strings.Repeat("长", 600), strings.Repeat("长", 600)}
+	}
+	embed := buildDiscordEmbed(d, 40)
+	if len(embed.Fields) > discordMaxFields {
+		t.Errorf("embed has %d fields", len(embed.Fields))
+	}
+	total := len([]rune(embed.Title)) + len([]rune(embed.Description))
+	for _, field := range embed.Fields {
+		if n := len([]rune(field.Value)); n > discordMaxFieldValue {
+			t.Errorf("field %q has %d characters", field.Name, n)
+		}
+		total += len([]rune(field.Name)) + len([]rune(field.Value))
+	}
+	if total > discordMaxTotal {
+		t.Errorf("embed has %d characters in total", total)
+	}
+	if last := embed.Fields[len(embed.Fields)-1]; last.Name != "…" {
+		t.Errorf("last field = %+v, want a note for the omitted courses", last)
+	}
+}
@@ -0,0 +1,357 @@
+// 邮件投递：将最新的日报或周报以 text/plain + text/html 双格式邮件逐个发送给订阅者
+package deliver
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+const (
+	emailFailuresFile = "email-failures.json"
+	smtpDialTimeout   = 30 * time.Second
+	smtpBatchTimeout  = 5 * time.Minute // 一批邮件在同一连接上发送的总时限
+)
+
+// EmailFailure 是一个发送失败的收件人及原因。
+type EmailFailure struct {
+	Recipient string `json:"recipient"`
+	Error     string `json:"error"`
+}
+
+// emailFailureLog 是失败记录文件的内容，每次发送后整体覆盖。
+type emailFailureLog struct {
+	Kind     string         `json:"kind"`
+	Title    string         `json:"title"`
+	SentAt   time.Time      `json:"sent_at"`
+	Total    int            `json:"total"`
+	Failures []EmailFailure `json:"failures"`
+}
+
+// Email 将最新一份 kind（daily 或 weekly）类型的报告发送给收件人列表中的每个地址，每封邮件只有一个收件人。
+// 收件人按 batch_size 分批，每批复用一个 SMTP 连接，批次之间等待 batch_interval。
+// 单个收件人失败不会中断发送，失败记录写到 failure_log；全部失败时返回错误。
+func Email(cfg *config.Config, kind string) error {
+	ec := cfg.Deliver.Email
+	if ec.Host == "" || ec.From == "" || ec.RecipientsFile == "" {
+		return errors.New("deliver.email.host, from and recipients_file must be configured")
+	}
+	published, err := report.LoadPublished(kind, cfg)
+	if err != nil {
+		return err
+	}
+	recipients, err := loadRecipients(ec.RecipientsFile)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		log.Printf("No email recipients in %s, skipped", ec.RecipientsFile)
+		return nil
+	}
+
+	m := newMailer(ec, os.Getenv("SMTP_PASSWORD"))
+	failures, err := m.deliver(published, recipients)
+	if err != nil {
+		return err
+	}
+	entry := emailFailureLog{Kind: kind, Title: published.Title, SentAt: m.now().UTC(), Total: len(recipients), Failures: failures}
+	if err := writeFailureLog(failureLogPath(ec), entry); err != nil {
+		log.Printf("Failed to write email failure log: %v", err)
+	}
+	log.Printf("Sent %s report to %d of %d recipients", kind, len(recipients)-len(failures), len(recipients))
+	if len(failures) == len(recipients) {
+		return fmt.Errorf("failed to send email to all %d recipients: %s", len(recipients), failures[0].Error)
+	}
+	return nil
+}
+
+// failureLogPath 返回失败记录的路径。失败记录包含收件人地址，默认与收件人列表放在一起，
+// 不写到会提交到仓库的 cache_dir。
+func failureLogPath(ec config.EmailConfig) string {
+	if ec.FailureLog != "" {
+		return ec.FailureLog
+	}
+	return filepath.Join(filepath.Dir(ec.RecipientsFile), emailFailuresFile)
+}
+
+// loadRecipients 读取收件人列表，每行一个地址（可带显示名），忽略空行和 # 开头的注释，
+// 按地址去重（不区分大小写），返回纯地址。
+func loadRecipients(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipients %q: %w", path, err)
+	}
+	var recipients []string
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addr, err := mail.ParseAddress(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient at %s:%d: %w", path, n, err)
+		}
+		key := strings.ToLower(addr.Address)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		recipients = append(recipients, addr.Address)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recipients %q: %w", path, err)
+	}
+	return recipients, nil
+}
+
+// mailer 通过 SMTP 分批发送邮件，tls、sleep 和 now 可在测试中替换。
+type mailer struct {
+	cfg      config.EmailConfig
+	password string
+	tls      *tls.Config // STARTTLS 使用的配置，为 nil 时按 Host 校验服务器证书
+	sleep    func(time.Duration)
+	now      func() time.Time
+}
+
+func newMailer(cfg config.EmailConfig, password string) *mailer {
+	return &mailer{cfg: cfg, password: password, sleep: time.Sleep, now: time.Now}
+}
+
+// deliver 将报告发送给 recipients，返回发送失败的收件人。只有报告无法渲染或发件人无效时返回错误。
+func (m *mailer) deliver(p report.Published, recipients []string) ([]EmailFailure, error) {
+	from, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", m.cfg.From, err)
+	}
+	html, err := p.HTML()
+	if err != nil {
+		return nil, err
+	}
+	text := plainText(p)
+	date := m.now()
+
+	var failures []EmailFailure
+	for start := 0; start < len(recipients); start += m.cfg.BatchSize {
+		if start > 0 {
+			m.sleep(m.cfg.BatchInterval)
+		}
+		batch := recipients[start:min(start+m.cfg.BatchSize, len(recipients))]
+		failures = append(failures, m.sendBatch(from.Address, batch, func(to string) ([]byte, error) {
+			return buildMessage(from, to, p.Title, text, html, date)
+		})...)
+	}
+	return failures, nil
+}
+
+// sendBatch 在一个 SMTP 连接上依次发送 batch 中的邮件。连接或认证失败时整批记为失败；
+// 单封邮件被拒收时重置会话继续发送，重置失败说明连接已不可用，其余收件人记为失败。
+func (m *mailer) sendBatch(from string, batch []string, message func(to string) ([]byte, error)) []EmailFailure {
+	failAll := func(recipients []string, err error) []EmailFailure {
+		failures := make([]EmailFailure, 0, len(recipients))
+		for _, to := range recipients {
+			failures = append(failures, EmailFailure{Recipient: to, Error: err.Error()})
+		}
+		return failures
+	}
+
+	c, err := m.dial()
+	if err != nil {
+		log.Printf("SMTP connection failed, %d recipients skipped: %v", len(batch), err)
+		return failAll(batch, err)
+	}
+	defer c.Close()
+
+	var failures []EmailFailure
+	for i, to := range batch {
+		err := sendOne(c, from, to, message)
+		if err == nil {
+			continue
+		}
+		log.Printf("Failed to send email to %s: %v", to, err)
+		failures = append(failures, EmailFailure{Recipient: to, Error: err.Error()})
+		if resetErr := c.Reset(); resetErr != nil {
+			return append(failures, failAll(batch[i+1:], fmt.Errorf("SMTP session lost: %w", resetErr))...)
+		}
+	}
+	if err := c.Quit(); err != nil {
+		log.Printf("SMTP QUIT failed: %v", err)
+	}
+	return failures
+}
+
+// dial 建立 SMTP 连接，按配置执行 STARTTLS 和认证。
+func (m *mailer) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	conn, err := net.DialTimeout("tcp", addr, smtpDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if err := conn.SetDeadline(m.now().Add(smtpBatchTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session with %s: %w", addr, err)
+	}
+	if m.cfg.StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			c.Close()
+			return nil, fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+		tlsConfig := m.tls
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: m.cfg.Host}
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("STARTTLS with %s failed: %w", addr, err)
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.password, m.cfg.Host)); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// sendOne 在当前会话中发送一封邮件。
+func sendOne(c *smtp.Client, from, to string, message func(to string) ([]byte, error)) error {
+	data, err := message(to)
+	if err != nil {
+		return err
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// plainText 返回邮件的纯文本部分：标题、Markdown 正文和报告链接。
+func plainText(p report.Published) string {
+	var b strings.Builder
+	b.WriteString(p.Title + "\n\n")
+	if p.Description != "" {
+		b.WriteString(p.Description + "\n\n")
+	}
+	b.WriteString(p.Body + "\n\n")
+	b.WriteString("在线阅读：" + p.URL + "\n")
+	return b.String()
+}
+
+// buildMessage 构造 multipart/alternative 邮件，两个部分均为 UTF-8 quoted-printable 编码。
+func buildMessage(from *mail.Address, to, subject, text, html string, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(crlf(part.content))); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	id, err := messageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+	var msg bytes.Buffer
+	for _, header := range [][2]string{
+		{"From", from.String()},
+		{"To", to},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", id},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": w.Boundary()})},
+	} {
+		msg.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// messageID 生成随机的 Message-ID，域名取自发件地址。
+func messageID(from string) (string, error) {
+	var buf [12]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	domain := "localhost"
+	if i := strings.LastIndexByte(from, '@'); i >= 0 {
+		domain = from[i+1:]
+	}
+	return "<" + hex.EncodeToString(buf[:]) + "@" + domain + ">", nil
+}
+
+// crlf 将换行统一为 CRLF，quoted-printable 编码会保留正文中的换行。
+func crlf(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
+
+func writeFailureLog(path string, entry emailFailureLog) error {
+	if entry.Failures == nil {
+		entry.Failures = []EmailFailure{}
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,340 @@
+package deliver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+// fakeSMTP 是本地 SMTP 替身，支持 STARTTLS 和 AUTH PLAIN，拒收 reject 中的收件人。
+type fakeSMTP struct {
+	listener net.Listener
+	tls      *tls.Config // 为 nil 时不宣告 STARTTLS
+	user     string
+	password string
+	reject   map[string]bool
+
+	mu          sync.Mutex
+	connections int
+	messages    map[string]string // 收件人 -> 原始邮件
+}
+
+func newFakeSMTP(t *testing.T, tlsConfig *tls.Config) *fakeSMTP {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &fakeSMTP{listener: l, tls: tlsConfig, user: "bot", password: "secret", reject: map[string]bool{}, messages: map[string]string{}}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connections++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) delivered() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages)
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+	secure := false
+	var rcpts []string
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			lines := []string{"fake"}
+			if s.tls != nil && !secure {
+				lines = append(lines, "STARTTLS")
+			}
+			lines = append(lines, "AUTH PLAIN")
+			for i, l := range lines {
+				sep := "-"
+				if i == len(lines)-1 {
+					sep = " "
+				}
+				tp.PrintfLine("250%s%s", sep, l)
+			}
+		case "STARTTLS":
+			tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, tp, secure = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			creds, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			if string(creds) == "\x00"+s.user+"\x00"+s.password {
+				tp.PrintfLine("235 authenticated")
+			} else {
+				tp.PrintfLine("535 bad credentials")
+			}
+		case "MAIL":
+			rcpts = nil
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			to := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if s.reject[to] {
+				tp.PrintfLine("550 mailbox unavailable")
+				continue
+			}
+			rcpts = append(rcpts, to)
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			for _, to := range rcpts {
+				s.messages[to] = string(data)
+			}
+			s.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case "RSET", "NOOP":
+			rcpts = nil
+			tp.PrintfLine("250 ok")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// selfSignedTLS 生成 127.0.0.1 的自签名证书，返回服务端配置和信任该证书的客户端配置。
+func selfSignedTLS(t *testing.T) (server, client *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}},
+		&tls.Config{RootCAs: pool, ServerName: "127.0.0.1"}
+}
+
+func testPublished() report.Published {
+	return report.Published{
+		Kind:        report.ReportKindWeekly,
+		Title:       "AUTO 周报 2026-02-06 - 2026-02-13",
+		Description: "涵盖 2026-02-06 至 2026-02-13 的更新",
+		Date:        "2026-02-13",
+		URL:         "https://hoa.moe/news/weekly/weekly-2026-02-13/",
+		Body:        "## 本周更新摘要\n\n- 电路原理：新增期末试卷。",
+	}
+}
+
+func TestMailer_Deliver(t *testing.T) {
+	serverTLS, clientTLS := selfSignedTLS(t)
+	server := newFakeSMTP(t, serverTLS)
+	server.reject["bounce@example.com"] = true
+
+	m := newMailer(config.EmailConfig{
+		Host:          "127.0.0.1",
+		Port:          server.port(),
+		Username:      "bot",
+		From:          "AUTO 周报 <news@hoa.moe>",
+		StartTLS:      true,
+		BatchSize:     2,
+		BatchInterval: 3 * time.Second,
+	}, "secret")
+	m.tls = clientTLS
+	var sleeps []time.Duration
+	m.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	recipients := []string{"a@example.com", "bounce@example.com", "c@example.com", "d@example.com", "e@example.com"}
+	failures, err := m.deliver(testPublished(), recipients)
+	if err != nil {
+		t.Fatalf("deliver() returned error: %v", err)
+	}
+	if len(failures) != 1 || failures[0].Recipient != "bounce@example.com" || !strings.Contains(failures[0].Error, "550") {
+		t.Errorf("failures = %+v, want only the rejected recipient", failures)
+	}
+	if len(sleeps) != 2 || sleeps[0] != 3*time.Second {
+		t.Errorf("sleeps = %v, want the batch interval between 3 batches", sleeps)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.connections != 3 {
+		t.Errorf("connections = %d, want one per batch", server.connections)
+	}
+	if len(server.messages) != 4 {
+		t.Fatalf("delivered %d messages, want 4", len(server.messages))
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(server.messages["c@example.com"]))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != testPublished().Title {
+		t.Errorf("Subject = %q", subject)
+	}
+	if msg.Header.Get("To") != "c@example.com" {
+		t.Errorf("To = %q, each message should have a single recipient", msg.Header.Get("To"))
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		content, _ := io.ReadAll(part) // NextPart 已解码 quoted-printable
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(content)
+	}
+	if text := parts["text/plain"]; !strings.Contains(text, "- 电路原理：新增期末试卷。") || !strings.Contains(text, testPublished().URL) {
+		t.Errorf("text part = %q", text)
+	}
+	if html := parts["text/html"]; !strings.Contains(html, "<li>电路原理：新增期末试卷。</li>") {
+		t.Errorf("html part = %q", html)
+	}
+}
+
+func TestMailer_ConnectionFailures(t *testing.T) {
+	serverTLS, clientTLS := selfSignedTLS(t)
+	cfg := config.EmailConfig{Host: "127.0.0.1", From: "news@hoa.moe", StartTLS: true, BatchSize: 10}
+
+	// 服务器不支持 STARTTLS 时不发送明文邮件
+	plain := newFakeSMTP(t, nil)
+	cfg.Port = plain.port()
+	failures, err := newMailer(cfg, "").deliver(testPublished(), []string{"a@example.com", "b@example.com"})
+	if err != nil || len(failures) != 2 || !strings.Contains(failures[0].Error, "STARTTLS") {
+		t.Errorf("deliver() without STARTTLS = %+v, %v", failures, err)
+	}
+
+	secure := newFakeSMTP(t, serverTLS)
+	cfg.Port, cfg.Username = secure.port(), "bot"
+	m := newMailer(cfg, "wrong")
+	m.tls = clientTLS
+	failures, err = m.deliver(testPublished(), []string{"a@example.com"})
+	if err != nil || len(failures) != 1 || !strings.Contains(failures[0].Error, "authentication") {
+		t.Errorf("deliver() with bad credentials = %+v, %v", failures, err)
+	}
+	if plain.delivered()+secure.delivered() != 0 {
+		t.Errorf("no message should be delivered")
+	}
+}
+
+func TestLoadRecipients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recipients.txt")
+	content := "# 订阅者\nalice@example.com\n\n张三 <zhang@example.com>\nALICE@example.com\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write recipients: %v", err)
+	}
+	recipients, err := loadRecipients(path)
+	if err != nil {
+		t.Fatalf("loadRecipients() returned error: %v", err)
+	}
+	if strings.Join(recipients, ",") != "alice@example.com,zhang@example.com" {
+		t.Errorf("recipients = %v", recipients)
+	}
+
+	if err := os.WriteFile(path, []byte("alice@example.com\nnot an address\n"), 0o644); err != nil {
+		t.Fatalf("failed to write recipients: %v", err)
+	}
+	if _, err := loadRecipients(path); err == nil || !strings.Contains(err.Error(), ":2") {
+		t.Errorf("expected error with line number, got %v", err)
+	}
+}
+
+func TestWriteFailureLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", emailFailuresFile)
+	if err := writeFailureLog(path, emailFailureLog{Kind: "daily", Total: 3}); err != nil {
+		t.Fatalf("writeFailureLog() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read failure log: %v", err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if failures, ok := entry["failures"].([]any); !ok || len(failures) != 0 {
+		t.Errorf("failures = %v, want an empty list so stale failures are cleared", entry["failures"])
+	}
+}
+
+func TestFailureLogPath(t *testing.T) {
+	ec := config.EmailConfig{RecipientsFile: filepath.Join("private", "recipients.txt")}
+	if got, want := failureLogPath(ec), filepath.Join("private", emailFailuresFile); got != want {
+		t.Errorf("failureLogPath() = %q, want %q next to the recipients file", got, want)
+	}
+	ec.FailureLog = filepath.Join("logs", "failures.json")
+	if got := failureLogPath(ec); got != ec.FailureLog {
+		t.Errorf("failureLogPath() = %q, want the configured path", got)
+	}
+}
@@ -0,0 +1,161 @@
+// 飞书群自定义机器人：以消息卡片推送报告摘要，支持签名校验
+package deliver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+// feishu 通过自定义机器人 webhook 发送消息卡片，见 https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot
+type feishu struct {
+	webhook    string
+	secret     string // 签名密钥，为空时不签名
+	maxCourses int
+	client     *http.Client
+	now        func() time.Time
+}
+
+func newFeishu(webhook, secret string, maxCourses int) *feishu {
+	return &feishu{webhook: webhook, secret: secret, maxCourses: maxCourses, client: &http.Client{Timeout: httpTimeout}, now: time.Now}
+}
+
+func (f *feishu) name() string { return "feishu" }
+
+// feishuMessage 是 webhook 的请求体，开启签名时带 timestamp 和 sign。
+type feishuMessage struct {
+	Timestamp string     `json:"timestamp,omitempty"`
+	Sign      string     `json:"sign,omitempty"`
+	MsgType   string     `json:"msg_type"`
+	Card      feishuCard `json:"card"`
+}
+
+type feishuCard struct {
+	Config   feishuCardConfig `json:"config"`
+	Header   feishuHeader     `json:"header"`
+	Elements []any            `json:"elements"`
+}
+
+type feishuCardConfig struct {
+	WideScreenMode bool `json:"wide_screen_mode"`
+}
+
+type feishuHeader struct {
+	Title    feishuText `json:"title"`
+	Template string     `json:"template"` // 标题栏颜色
+}
+
+// feishuText 是卡片中的文本，Tag 为 plain_text 或 lark_md。
+type feishuText struct {
+	Tag     string `json:"tag"`
+	Content string `json:"content"`
+}
+
+type feishuDiv struct {
+	Tag    string        `json:"tag"` // 固定为 div
+	Text   *feishuText   `json:"text,omitempty"`
+	Fields []feishuField `json:"fields,omitempty"`
+}
+
+type feishuField struct {
+	IsShort bool       `json:"is_short"`
+	Text    feishuText `json:"text"`
+}
+
+type feishuHR struct {
+	Tag string `json:"tag"` // 固定为 hr
+}
+
+type feishuAction struct {
+	Tag     string         `json:"tag"` // 固定为 action
+	Actions []feishuButton `json:"actions"`
+}
+
+type feishuButton struct {
+	Tag  string     `json:"tag"` // 固定为 button
+	Text feishuText `json:"text"`
+	URL  string     `json:"url"`
+	Type string     `json:"type"` // default、primary
+}
+
+// feishuResponse 是 webhook 的响应，Code 非 0 表示失败（如签名校验失败、触发频率限制）。
+type feishuResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func (f *feishu) send(d Digest) error {
+	msg := feishuMessage{MsgType: "interactive", Card: buildFeishuCard(d, f.maxCourses)}
+	if f.secret != "" {
+		timestamp := f.now().Unix()
+		msg.Timestamp = strconv.FormatInt(timestamp, 10)
+		msg.Sign = feishuSign(f.secret, timestamp)
+	}
+	var resp feishuResponse
+	if err := postJSON(f.client, f.webhook, msg, &resp); err != nil {
+		return err
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("feishu returned code %d: %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// feishuSign 计算签名：以 "timestamp\nsecret" 为密钥对空消息做 HMAC-SHA256，再 Base64 编码。
+func feishuSign(secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(timestamp, 10)+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// buildFeishuCard 构建消息卡片：周报摘要、日报的新 issue 和 PR 数、每门课程一节，最后是跳转按钮。
+func buildFeishuCard(d Digest, maxCourses int) feishuCard {
+	template := "blue"
+	if d.Kind == report.ReportKindWeekly {
+		template = "green"
+	}
+	card := feishuCard{
+		Config: feishuCardConfig{WideScreenMode: true},
+		Header: feishuHeader{Title: feishuText{Tag: "plain_text", Content: d.Title}, Template: template},
+	}
+	markdown := func(content string) feishuDiv {
+		return feishuDiv{Tag: "div", Text: &feishuText{Tag: "lark_md", Content: content}}
+	}
+
+	if d.Summary != "" {
+		card.Elements = append(card.Elements, markdown(d.Summary), feishuHR{Tag: "hr"})
+	}
+	if d.Kind == report.ReportKindDaily {
+		card.Elements = append(card.Elements, feishuDiv{Tag: "div", Fields: []feishuField{
+			{IsShort: true, Text: feishuText{Tag: "lark_md", Content: fmt.Sprintf("**提交**\n%d", d.Commits)}},
+			{IsShort: true, Text: feishuText{Tag: "lark_md", Content: fmt.Sprintf("**新 Issue**\n%d", d.NewIssues)}},
+			{IsShort: true, Text: feishuText{Tag: "lark_md", Content: fmt.Sprintf("**新 PR**\n%d", d.NewPRs)}},
+		}})
+	}
+
+	courses, omitted := limitCourses(d.Courses, maxCourses)
+	for _, course := range courses {
+		card.Elements = append(card.Elements, markdown(courseMarkdown(course)))
+	}
+	if omitted > 0 {
+		card.Elements = append(card.Elements, markdown(fmt.Sprintf("另有 %d 门课程有更新，详见完整报告。", omitted)))
+	}
+	if len(d.Courses) == 0 && d.Summary == "" {
+		card.Elements = append(card.Elements, markdown("暂无更新"))
+	}
+
+	buttons := []feishuButton{{Tag: "button", Text: feishuText{Tag: "plain_text", Content: "查看完整报告"}, URL: d.URL, Type: "primary"}}
+	if d.Kind == report.ReportKindDaily {
+		buttons = append(buttons,
+			feishuButton{Tag: "button", Text: feishuText{Tag: "plain_text", Content: fmt.Sprintf("新 Issue (%d)", d.NewIssues)}, URL: d.IssuesURL, Type: "default"},
+			feishuButton{Tag: "button", Text: feishuText{Tag: "plain_text", Content: fmt.Sprintf("新 PR (%d)", d.NewPRs)}, URL: d.PRsURL, Type: "default"},
+		)
+	}
+	card.Elements = append(card.Elements, feishuAction{Tag: "action", Actions: buttons})
+	return card
+}
@@ -0,0 +1,62 @@
+package deliver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeishu_Send(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		// 与飞书服务端相同的校验：用 timestamp 和密钥重新计算签名
+		if got["sign"] != feishuSign("s3cret", 1770710400) || got["timestamp"] != "1770710400" {
+			w.Write([]byte(`{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}`))
+			return
+		}
+		w.Write([]byte(`{"code":0,"msg":"success","data":{}}`))
+	}))
+	defer server.Close()
+
+	f := newFeishu(server.URL, "s3cret", 1)
+	f.now = func() time.Time { return time.Unix(1770710400, 0) }
+	if err := f.send(newDigest(testDailyPublished(), "HITSZ-OpenAuto")); err != nil {
+		t.Fatalf("send() returned error: %v", err)
+	}
+	if got["msg_type"] != "interactive" {
+		t.Errorf("msg_type = %v", got["msg_type"])
+	}
+	card, _ := json.Marshal(got["card"])
+	for _, want := range []string{
+		`"content":"AUTO 更新速递"`,
+		`**[电路原理](https://github.com/HITSZ-OpenAuto/EE3001)** · 3 条提交\n- 上传试卷\n- 修正答案`,
+		`另有 1 门课程有更新`,
+		`"content":"新 Issue (1)"`,
+		`"url":"https://hoa.moe/news/daily/"`,
+	} {
+		if !strings.Contains(string(card), want) {
+			t.Errorf("card missing %q:\n%s", want, card)
+		}
+	}
+
+	f.secret = "wrong"
+	if err := f.send(newDigest(testDailyPublished(), "HITSZ-OpenAuto")); err == nil || !strings.Contains(err.Error(), "19021") {
+		t.Errorf("expected error for rejected signature, got %v", err)
+	}
+}
+
+func TestBuildFeishuCard_Weekly(t *testing.T) {
+	d := Digest{Kind: "weekly", Title: "AUTO 周报", URL: "https://hoa.moe/news/weekly/weekly-2026-02-13/", Summary: "- **电路原理**：新增期末试卷"}
+	card, _ := json.Marshal(buildFeishuCard(d, 8))
+	if !strings.Contains(string(card), `"content":"- **电路原理**：新增期末试卷"`) || strings.Contains(string(card), "新 Issue") {
+		t.Errorf("weekly card should contain the summary and no issue counts:\n%s", card)
+	}
+}
@@ -0,0 +1,224 @@
+// 报告 Markdown 到各推送平台格式的转换。报告中的外部文本已按 internal/utils 的规则转义为 HTML 实体，
+// 链接文字中的 []()\ 带反斜杠，这里先还原为原文，再按目标平台的规则重新转义。
+package deliver
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// markup 描述一种目标格式。text 转义纯文本，bold 包裹已转换的内容，link 的 label 为原文。
+type markup struct {
+	text   func(s string) string
+	bold   func(s string) string
+	link   func(label, url string) string
+	bullet string // 列表项前缀
+}
+
+var (
+	// plainMarkup 输出纯文本，链接只保留文字，用于 QQ。
+	plainMarkup = markup{
+		text:   func(s string) string { return s },
+		bold:   func(s string) string { return s },
+		link:   func(label, _ string) string { return label },
+		bullet: "· ",
+	}
+
+	// telegramMarkup 输出 Telegram 的 MarkdownV2，见 https://core.telegram.org/bots/api#markdownv2-style
+	telegramMarkup = markup{
+		text: telegramEscaper.Replace,
+		bold: func(s string) string { return "*" + s + "*" },
+		link: func(label, url string) string {
+			return "[" + telegramEscaper.Replace(label) + "](" + telegramURLEscaper.Replace(url) + ")"
+		},
+		bullet: "• ",
+	}
+
+	// discordMarkup 输出 Discord 的 Markdown，embed 的描述和字段中链接可用。
+	discordMarkup = markup{
+		text: discordEscaper.Replace,
+		bold: func(s string) string { return "**" + s + "**" },
+		link: func(label, url string) string {
+			return "[" + discordEscaper.Replace(label) + "](" + url + ")"
+		},
+		bullet: "- ",
+	}
+
+	// slackMarkup 输出 Slack 的 mrkdwn，见 https://api.slack.com/reference/surfaces/formatting
+	slackMarkup = markup{
+		text: slackEscaper.Replace,
+		bold: func(s string) string { return "*" + s + "*" },
+		link: func(label, url string) string {
+			return "<" + url + "|" + slackEscaper.Replace(strings.ReplaceAll(label, "|", "¦")) + ">"
+		},
+		bullet: "• ",
+	}
+)
+
+var (
+	telegramEscaper = strings.NewReplacer(
+		`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`, "`", "\\`",
+		">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+	)
+	telegramURLEscaper = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+	discordEscaper     = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, "[", `\[`, "]", `\]`, ">", `\>`, "#", `\#`)
+	slackEscaper       = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// maxLineRunes 是转义前单行原文的最大字符数。超长的行在转义前截断，转义后的行不会超过各平台的消息长度，
+// 之后无需再截断，避免截断 MarkdownV2 的转义序列、加粗或链接。
+const maxLineRunes = 500
+
+// cleanText 按 utils.SanitizeInlineText 的规则清理原始文本（提交信息、课程名等），还原为不含实体的原文，
+// 超过 maxLineRunes 时截断。
+func cleanText(s string) string {
+	return truncateRunes(html.UnescapeString(utils.SanitizeInlineText(s)), maxLineRunes)
+}
+
+// urlEscaper 对地址中会截断各平台链接语法的字符做百分号编码。
+var urlEscaper = strings.NewReplacer("(", "%28", ")", "%29", "<", "%3C", ">", "%3E", "|", "%7C", " ", "%20")
+
+// linkURL 按 utils.SanitizeURL 校验地址，只接受 http 和 https 链接。
+func linkURL(raw string) (string, bool) {
+	safe, ok := utils.SanitizeURL(raw)
+	if !ok || !(strings.HasPrefix(safe, "https://") || strings.HasPrefix(safe, "http://")) {
+		return "", false
+	}
+	return urlEscaper.Replace(safe), true
+}
+
+// renderMarkdown 将报告中的 Markdown（如 AI 摘要）转换为目标格式。支持标题、列表项、加粗和链接，
+// 标题转换为加粗的一行，其余标记按纯文本处理。每行先按 maxLineRunes 截断再转换，截断处未闭合的加粗和链接按纯文本输出。
+func renderMarkdown(md string, m markup) string {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(md, "\r\n", "\n")), "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(truncateRunes(line, maxLineRunes), " ")
+		trimmed := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(trimmed)]
+		switch {
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			lines[i] = indent + m.bullet + renderInline(trimmed[2:], m)
+		case strings.HasPrefix(trimmed, "#"):
+			// 整行加粗，去掉标题内部的加粗标记，避免嵌套
+			lines[i] = m.bold(renderInline(strings.ReplaceAll(strings.TrimLeft(trimmed, "# "), "**", ""), m))
+		default:
+			lines[i] = renderInline(line, m)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderInline 转换一行中的加粗（**）和链接，未闭合的加粗按纯文本输出。
+func renderInline(line string, m markup) string {
+	var out, bold strings.Builder
+	inBold := false
+	target := &out
+	var text strings.Builder // 待转义的原文
+	flush := func() {
+		if text.Len() > 0 {
+			target.WriteString(m.text(html.UnescapeString(text.String())))
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); {
+		switch {
+		case strings.HasPrefix(line[i:], "**"):
+			flush()
+			if inBold {
+				out.WriteString(m.bold(bold.String()))
+				bold.Reset()
+				target = &out
+			} else {
+				target = &bold
+			}
+			inBold = !inBold
+			i += 2
+		case line[i] == '\\' && i+1 < len(line) && strings.IndexByte(`\[]()*_|#>~-`, line[i+1]) >= 0:
+			text.WriteByte(line[i+1])
+			i += 2
+		case line[i] == '[':
+			label, url, n, ok := parseMarkdownLink(line[i:])
+			if !ok {
+				text.WriteByte('[')
+				i++
+				continue
+			}
+			flush()
+			if safeURL, valid := linkURL(url); valid {
+				target.WriteString(m.link(label, safeURL))
+			} else {
+				target.WriteString(m.text(label))
+			}
+			i += n
+		default:
+			text.WriteByte(line[i])
+			i++
+		}
+	}
+	flush()
+	if inBold { // 未闭合的加粗还原为原文
+		out.WriteString(m.text("**"))
+		out.WriteString(bold.String())
+	}
+	return out.String()
+}
+
+// parseMarkdownLink 解析 s 开头的 [label](url)，返回还原后的链接文字、地址和消耗的字节数。
+func parseMarkdownLink(s string) (label, url string, n int, ok bool) {
+	var b strings.Builder
+	i := 1
+	for ; i < len(s) && s[i] != ']'; i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	if i+1 >= len(s) || s[i+1] != '(' {
+		return "", "", 0, false
+	}
+	end := strings.IndexByte(s[i+2:], ')')
+	if end < 0 {
+		return "", "", 0, false
+	}
+	url = s[i+2 : i+2+end]
+	if strings.ContainsAny(url, " \t") {
+		return "", "", 0, false
+	}
+	return html.UnescapeString(b.String()), url, i + 2 + end + 1, true
+}
+
+// renderCourse 返回一门课程的段落：加粗的课程链接、提交数和最多 maxCourseMessages 条提交信息。
+func renderCourse(course DigestCourse, m markup) string {
+	name := cleanText(course.Name)
+	head := m.text(name)
+	if url, ok := linkURL(course.URL); ok {
+		head = m.link(name, url)
+	}
+	lines := append([]string{m.bold(head) + m.text(fmt.Sprintf(" · %d 条提交", course.Commits))}, courseMessages(course, m)...)
+	return strings.Join(lines, "\n")
+}
+
+// courseMessages 返回课程的提交信息列表项，最多 maxCourseMessages 条，其余只给出条数。
+func courseMessages(course DigestCourse, m markup) []string {
+	messages := course.Messages
+	if len(messages) > maxCourseMessages {
+		messages = messages[:maxCourseMessages]
+	}
+	lines := make([]string, 0, len(messages)+1)
+	for _, message := range messages {
+		lines = append(lines, m.bullet+m.text(cleanText(message)))
+	}
+	if more := len(course.Messages) - len(messages); more > 0 {
+		lines = append(lines, m.bullet+m.text(fmt.Sprintf("…另有 %d 条", more)))
+	}
+	return lines
+}
+
+// dailyStats 返回日报的统计行。
+func dailyStats(d Digest) string {
+	return fmt.Sprintf("提交 %d 条，新 Issue %d 个，新 PR %d 个", d.Commits, d.NewIssues, d.NewPRs)
+}
@@ -0,0 +1,51 @@
+package deliver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	md := "## 本周亮点\n\n- **[电路原理 \\[2026\\]](https://github.com/HITSZ-OpenAuto/EE3001)**：新增期末试卷 &amp; 答案 (v1.0)!\n- 见 [链接](javascript:alert%281%29)"
+	tests := []struct {
+		name string
+		m    markup
+		want string
+	}{
+		{"plain", plainMarkup, "本周亮点\n\n· 电路原理 [2026]：新增期末试卷 & 答案 (v1.0)!\n· 见 链接"},
+		{"telegram", telegramMarkup, "*本周亮点*\n\n• *[电路原理 \\[2026\\]](https://github.com/HITSZ-OpenAuto/EE3001)*：新增期末试卷 & 答案 \\(v1\\.0\\)\\!\n• 见 链接"},
+		{"discord", discordMarkup, "**本周亮点**\n\n- **[电路原理 \\[2026\\]](https://github.com/HITSZ-OpenAuto/EE3001)**：新增期末试卷 & 答案 (v1.0)!\n- 见 链接"},
+		{"slack", slackMarkup, "*本周亮点*\n\n• *<https://github.com/HITSZ-OpenAuto/EE3001|电路原理 [2026]>*：新增期末试卷 &amp; 答案 (v1.0)!\n• 见 链接"},
+	}
+	for _, tt := range tests {
+		if got := renderMarkdown(md, tt.m); got != tt.want {
+			t.Errorf("renderMarkdown(%s) =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMarkdown_BoldHeading(t *testing.T) {
+	if got, want := renderMarkdown("### **本周** 摘要", telegramMarkup), "*本周 摘要*"; got != want {
+		t.Errorf("renderMarkdown(telegram) = %q, want %q", got, want)
+	}
+	if got, want := renderMarkdown("## **本周** 摘要", discordMarkup), "**本周 摘要**"; got != want {
+		t.Errorf("renderMarkdown(discord) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderCourse(t *testing.T) {
+	course := DigestCourse{
+		Name:     "C++ 程序设计",
+		URL:      "https://github.com/HITSZ-OpenAuto/CS2001",
+		Commits:  5,
+		Messages: []string{"fix: 修正 <实验> 答案_v2", "a", "b", "c"},
+	}
+	want := "*[C\\+\\+ 程序设计](https://github.com/HITSZ-OpenAuto/CS2001)* · 5 条提交\n" +
+		"• fix: 修正 <实验\\> 答案\\_v2\n• a\n• b\n• …另有 1 条"
+	if got := renderCourse(course, telegramMarkup); got != want {
+		t.Errorf("renderCourse(telegram) =\n%s\nwant\n%s", got, want)
+	}
+	if got := renderCourse(course, slackMarkup); !strings.HasPrefix(got, "*<https://") || !strings.Contains(got, "修正 &lt;实验&gt; 答案_v2") {
+		t.Errorf("renderCourse(slack) = %s", got)
+	}
+}
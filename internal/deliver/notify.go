@@ -0,0 +1,191 @@
+// 群聊推送：daily 和 weekly 生成报告后，将摘要推送到已开启的群机器人，内容与上次推送实质相同时跳过
+package deliver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+const (
+	notifyStateFile = "notified.json"
+	httpTimeout     = 15 * time.Second
+)
+
+// notifier 是一个群聊推送渠道。
+type notifier interface {
+	name() string // 渠道名，同时用作推送记录的键
+	send(d Digest) error
+}
+
+// newNotifiers 按配置创建推送 kind 类型报告的渠道，缺少必需的环境变量时返回错误。
+func newNotifiers(cfg config.DeliverConfig, kind string) ([]notifier, error) {
+	var notifiers []notifier
+	if cfg.Feishu.Enabled {
+		webhook := os.Getenv("FEISHU_WEBHOOK")
+		if webhook == "" {
+			return nil, errors.New("deliver.feishu is enabled but FEISHU_WEBHOOK is not set")
+		}
+		notifiers = append(notifiers, newFeishu(webhook, os.Getenv("FEISHU_SECRET"), cfg.MaxCourses))
+	}
+	if cfg.DingTalk.Enabled {
+		webhook := os.Getenv("DINGTALK_WEBHOOK")
+		if webhook == "" {
+			return nil, errors.New("deliver.dingtalk is enabled but DINGTALK_WEBHOOK is not set")
+		}
+		notifiers = append(notifiers, newDingTalk(webhook, os.Getenv("DINGTALK_SECRET"), cfg.DingTalk.MsgType, cfg.MaxCourses))
+	}
+	if cfg.QQ.Enabled && kind == report.ReportKindWeekly { // QQ 群只推送周报
+		notifiers = append(notifiers, newOneBot(cfg.QQ.Endpoint, os.Getenv("ONEBOT_ACCESS_TOKEN"), cfg.QQ.Groups, cfg.QQ.MaxLength))
+	}
+	if cfg.Telegram.Enabled {
+		token := os.Getenv("TELEGRAM_BOT_TOKEN")
+		if token == "" {
+			return nil, errors.New("deliver.telegram is enabled but TELEGRAM_BOT_TOKEN is not set")
+		}
+		notifiers = append(notifiers, newTelegram(token, cfg.Telegram.ChatIDs, cfg.MaxCourses))
+	}
+	if cfg.Discord.Enabled {
+		webhook := os.Getenv("DISCORD_WEBHOOK")
+		if webhook == "" {
+			return nil, errors.New("deliver.discord is enabled but DISCORD_WEBHOOK is not set")
+		}
+		notifiers = append(notifiers, newDiscord(webhook, cfg.MaxCourses))
+	}
+	if cfg.Slack.Enabled {
+		webhook := os.Getenv("SLACK_WEBHOOK")
+		if webhook == "" {
+			return nil, errors.New("deliver.slack is enabled but SLACK_WEBHOOK is not set")
+		}
+		notifiers = append(notifiers, newSlack(webhook, cfg.MaxCourses))
+	}
+	return notifiers, nil
+}
+
+// Notify 将最新一份 kind 类型的报告推送到所有已开启的渠道。每个渠道的推送内容记录在
+// <cache_dir>/notified.json 中，报告正文与上次推送实质相同时跳过该渠道。
+// 单个渠道失败不影响其他渠道，返回的错误包含所有失败的渠道。
+func Notify(cfg *config.Config, kind string) error {
+	notifiers, err := newNotifiers(cfg.Deliver, kind)
+	if err != nil {
+		return err
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+	published, err := report.LoadPublished(kind, cfg)
+	if err != nil {
+		return err
+	}
+	return notify(notifiers, published, filepath.Join(cfg.CacheDir, notifyStateFile), config.OrgName)
+}
+
+func notify(notifiers []notifier, p report.Published, statePath, orgName string) error {
+	state, err := loadNotifyState(statePath)
+	if err != nil {
+		return err
+	}
+	digest := newDigest(p, orgName)
+	var errs []error
+	for _, n := range notifiers {
+		key := n.name() + "/" + p.Kind
+		if previous, ok := state[key]; ok && p.SameAs(previous) {
+			log.Printf("Skipped %s notification: %s report unchanged", n.name(), p.Kind)
+			continue
+		}
+		if err := n.send(digest); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify %s: %w", n.name(), err))
+			continue
+		}
+		state[key] = p.Body
+		log.Printf("Sent %s report to %s", p.Kind, n.name())
+	}
+	if err := state.save(statePath); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// notifyState 记录每个渠道每种报告上次推送的正文，键为 "<渠道>/<报告类型>"。
+type notifyState map[string]string
+
+// loadNotifyState 读取推送记录，文件不存在时返回空记录。
+func loadNotifyState(path string) (notifyState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return notifyState{}, nil
+		}
+		return nil, fmt.Errorf("failed to read notification state %q: %w", path, err)
+	}
+	state := notifyState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse notification state %q: %w", path, err)
+	}
+	return state, nil
+}
+
+func (s notifyState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write notification state %q: %w", path, err)
+	}
+	return nil
+}
+
+// postJSON 以 JSON 发送 payload，非 2xx 状态码视为失败；result 非 nil 时解码响应。
+func postJSON(client *http.Client, target string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	resp, err := client.Post(target, "application/json; charset=utf-8", bytes.NewReader(body))
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) { // webhook 地址中带有 token，不写入错误信息
+			return fmt.Errorf("request failed: %w", urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, truncateRunes(string(data), 200))
+	}
+	if result != nil {
+		if err := json.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
+
+// truncateRunes 将 s 截断到最多 n 个字符，截断时以省略号结尾。
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,131 @@
+package deliver
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+// fakeNotifier 记录收到的摘要，err 非 nil 时发送失败。
+type fakeNotifier struct {
+	sent []Digest
+	err  error
+}
+
+func (f *fakeNotifier) name() string { return "fake" }
+
+func (f *fakeNotifier) send(d Digest) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, d)
+	return nil
+}
+
+func testDailyPublished() report.Published {
+	start := time.Date(2026, 2, 9, 16, 0, 0, 0, time.UTC)
+	return report.Published{
+		Kind:  report.ReportKindDaily,
+		Title: "AUTO 更新速递",
+		URL:   "https://hoa.moe/news/daily/",
+		Body:  "## 最近更新\n\n- 张三 在 电路原理 中提交了信息：上传试卷",
+		Document: &report.ReportDocument{
+			Kind:   report.ReportKindDaily,
+			Window: report.ReportWindow{Start: start, End: start.Add(24 * time.Hour)},
+			Commits: []report.ReportCommit{
+				{Repo: "EE3001", Message: "上传试卷", Date: start.Add(3 * time.Hour)},
+				{Repo: "CS1001", Message: "整理目录", Date: start.Add(2 * time.Hour)},
+				{Repo: "EE3001", Message: "上传试卷", Date: start.Add(time.Hour)},
+				{Repo: "EE3001", Message: "修正答案", Date: start.Add(time.Hour)},
+			},
+			Courses: map[string]report.CourseMeta{"EE3001": {Name: "电路原理"}},
+			Issues: []report.ReportItem{
+				{Title: "新 issue", CreatedAt: "2026-02-10T01:00:00Z"},
+				{Title: "旧 issue", CreatedAt: "2026-01-01T01:00:00Z"},
+			},
+			PullRequests: []report.ReportItem{{Title: "新 PR", CreatedAt: "2026-02-10T02:00:00Z"}},
+		},
+	}
+}
+
+func TestNewDigest(t *testing.T) {
+	d := newDigest(testDailyPublished(), "HITSZ-OpenAuto")
+	if d.Commits != 4 || d.NewIssues != 1 || d.NewPRs != 1 {
+		t.Errorf("digest counts = %d commits, %d issues, %d PRs", d.Commits, d.NewIssues, d.NewPRs)
+	}
+	if len(d.Courses) != 2 {
+		t.Fatalf("courses = %+v", d.Courses)
+	}
+	first := d.Courses[0]
+	if first.Name != "电路原理" || first.Commits != 3 || len(first.Messages) != 2 || first.URL != "https://github.com/HITSZ-OpenAuto/EE3001" {
+		t.Errorf("first course = %+v, want the most active course with deduplicated messages", first)
+	}
+	if want := "https://github.com/issues?q=is%3Aopen+is%3Aissue+org%3AHITSZ-OpenAuto+created%3A%3E%3D2026-02-09T16%3A00%3A00Z"; d.IssuesURL != want {
+		t.Errorf("IssuesURL = %q, want %q to match the counted window", d.IssuesURL, want)
+	}
+	if !strings.HasSuffix(d.PRsURL, "created%3A%3E%3D2026-02-09T16%3A00%3A00Z") || !strings.HasPrefix(d.PRsURL, "https://github.com/pulls?q=is%3Aopen+is%3Apr+") {
+		t.Errorf("PRsURL = %q", d.PRsURL)
+	}
+	if d.Courses[1].Name != "CS1001" {
+		t.Errorf("courses without metadata should use the repo name, got %q", d.Courses[1].Name)
+	}
+
+	p := testDailyPublished()
+	p.Document = nil
+	if d := newDigest(p, "HITSZ-OpenAuto"); d.Title != p.Title || len(d.Courses) != 0 {
+		t.Errorf("digest without JSON output = %+v", d)
+	}
+}
+
+func TestNotify_SkipsUnchanged(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "cache", notifyStateFile)
+	n := &fakeNotifier{}
+	p := testDailyPublished()
+
+	for i := 0; i < 2; i++ {
+		if err := notify([]notifier{n}, p, statePath, "HITSZ-OpenAuto"); err != nil {
+			t.Fatalf("notify() returned error: %v", err)
+		}
+	}
+	if len(n.sent) != 1 {
+		t.Fatalf("sent %d times, want the unchanged report to be skipped", len(n.sent))
+	}
+
+	// 只有空白差异时仍视为未变化
+	p.Body += "\n\n"
+	if err := notify([]notifier{n}, p, statePath, "HITSZ-OpenAuto"); err != nil || len(n.sent) != 1 {
+		t.Errorf("whitespace-only change should be skipped, sent %d, err %v", len(n.sent), err)
+	}
+
+	p.Body += "\n- 李四 在 计算机导论 中提交了信息：整理目录"
+	failing := &fakeNotifier{err: errors.New("rate limited")}
+	if err := notify([]notifier{failing}, p, statePath, "HITSZ-OpenAuto"); err == nil {
+		t.Fatalf("expected error from failing notifier")
+	}
+	if err := notify([]notifier{n}, p, statePath, "HITSZ-OpenAuto"); err != nil || len(n.sent) != 2 {
+		t.Errorf("changed report should be sent after a failed attempt, sent %d, err %v", len(n.sent), err)
+	}
+}
+
+func TestNewNotifiers(t *testing.T) {
+	cfg := config.DeliverConfig{
+		MaxCourses: 8,
+		Feishu:     config.FeishuConfig{Enabled: true},
+		QQ:         config.QQConfig{Enabled: true, Endpoint: "http://127.0.0.1:3000", Groups: []int64{111}, MaxLength: 1500},
+	}
+	if _, err := newNotifiers(cfg, report.ReportKindDaily); err == nil {
+		t.Errorf("expected error when FEISHU_WEBHOOK is not set")
+	}
+	t.Setenv("FEISHU_WEBHOOK", "https://open.feishu.cn/open-apis/bot/v2/hook/x")
+	for kind, want := range map[string]int{report.ReportKindDaily: 1, report.ReportKindWeekly: 2} {
+		notifiers, err := newNotifiers(cfg, kind)
+		if err != nil || len(notifiers) != want {
+			t.Errorf("newNotifiers(%s) = %d notifiers, %v; want %d (QQ only receives weekly reports)", kind, len(notifiers), err, want)
+		}
+	}
+}
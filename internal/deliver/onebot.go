@@ -0,0 +1,160 @@
+// QQ 群推送：通过 OneBot v11 HTTP API（go-cqhttp、NapCat、Lagrange 等）发送周报的纯文本摘要
+package deliver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// onebotMessageInterval 是相邻两条消息之间的等待时间，避免连续发送触发 QQ 的风控。
+const onebotMessageInterval = time.Second
+
+// onebot 调用 OneBot v11 的 send_group_msg 接口，见 https://github.com/botuniverse/onebot-11/blob/master/api/public.md
+type onebot struct {
+	endpoint  string  // HTTP API 地址，如 http://127.0.0.1:3000
+	groups    []int64 // 目标群号
+	maxLength int     // 单条消息的最大字符数
+	client    *http.Client
+	sleep     func(time.Duration)
+}
+
+func newOneBot(endpoint, token string, groups []int64, maxLength int) *onebot {
+	client := &http.Client{Timeout: httpTimeout}
+	if token != "" {
+		client.Transport = bearerTransport{token: token}
+	}
+	return &onebot{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		groups:    groups,
+		maxLength: maxLength,
+		client:    client,
+		sleep:     time.Sleep,
+	}
+}
+
+func (o *onebot) name() string { return "qq" }
+
+type onebotGroupMessage struct {
+	GroupID    int64  `json:"group_id"`
+	Message    string `json:"message"`
+	AutoEscape bool   `json:"auto_escape"` // 按纯文本发送，不解析 CQ 码
+}
+
+// onebotResponse 是 OneBot 的响应，Status 为 failed 或 RetCode 非 0 表示失败（如机器人不在群中）。
+type onebotResponse struct {
+	Status  string `json:"status"`
+	RetCode int    `json:"retcode"`
+	Message string `json:"message"`
+	Wording string `json:"wording"`
+}
+
+// send 将摘要拆分为若干条消息依次发往每个群。某个群发送失败时跳过该群剩余的消息，继续发送其他群。
+func (o *onebot) send(d Digest) error {
+	messages := splitMessages(plainSections(d), o.maxLength, func(i, n int) string {
+		return fmt.Sprintf("(%d/%d) ", i, n)
+	})
+	var errs []error
+	first := true
+	for _, group := range o.groups {
+		for _, message := range messages {
+			if !first {
+				o.sleep(onebotMessageInterval)
+			}
+			first = false
+			if err := o.sendGroupMessage(group, message); err != nil {
+				errs = append(errs, fmt.Errorf("group %d: %w", group, err))
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *onebot) sendGroupMessage(group int64, message string) error {
+	var resp onebotResponse
+	if err := postJSON(o.client, o.endpoint+"/send_group_msg", onebotGroupMessage{GroupID: group, Message: message, AutoEscape: true}, &resp); err != nil {
+		return err
+	}
+	if resp.Status == "failed" || resp.RetCode != 0 {
+		return fmt.Errorf("onebot returned retcode %d: %s %s", resp.RetCode, resp.Message, resp.Wording)
+	}
+	return nil
+}
+
+// bearerTransport 为请求加上 OneBot 的 access token 认证头 Authorization: Bearer <token>。
+type bearerTransport struct {
+	token string
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// plainSections 将摘要转换为纯文本段落：标题、AI 摘要、每门课程一段，最后是报告链接。
+func plainSections(d Digest) []string {
+	sections := []string{d.Title}
+	if d.Summary != "" {
+		sections = append(sections, "本周更新摘要：\n"+renderMarkdown(d.Summary, plainMarkup))
+	}
+	for _, course := range d.Courses {
+		lines := append([]string{fmt.Sprintf("【%s】%d 条提交", cleanText(course.Name), course.Commits)}, courseMessages(course, plainMarkup)...)
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	return append(sections, "完整报告："+d.URL)
+}
+
+// splitMessages 将段落拼成不超过 maxLength 个字符的消息，只在段落（课程）之间拆分；
+// 单个段落超长时在行之间拆分，单行仍超长时按字符截断。带格式的段落（如 MarkdownV2）截断后可能无效，
+// 调用方应保证转义后的单行不超过 maxLength，见 maxLineRunes。拆成多条时每条开头带上 index(序号, 总数) 返回的前缀。
+func splitMessages(sections []string, maxLength int, index func(i, n int) string) []string {
+	limit := maxLength - len([]rune(index(99, 99))) // 为序号预留长度
+	var chunks []string
+	for _, section := range sections {
+		if len([]rune(section)) <= limit {
+			chunks = append(chunks, section)
+			continue
+		}
+		piece := ""
+		for _, line := range strings.Split(section, "\n") {
+			line = truncateRunes(line, limit)
+			if piece != "" && len([]rune(piece+"\n"+line)) > limit {
+				chunks = append(chunks, piece)
+				piece = ""
+			}
+			if piece != "" {
+				piece += "\n"
+			}
+			piece += line
+		}
+		chunks = append(chunks, piece)
+	}
+
+	var messages []string
+	current := ""
+	for _, chunk := range chunks {
+		if current == "" {
+			current = chunk
+			continue
+		}
+		if next := current + "\n\n" + chunk; len([]rune(next)) <= limit {
+			current = next
+			continue
+		}
+		messages = append(messages, current)
+		current = chunk
+	}
+	if current != "" {
+		messages = append(messages, current)
+	}
+	if len(messages) > 1 {
+		for i := range messages {
+			messages[i] = index(i+1, len(messages)) + messages[i]
+		}
+	}
+	return messages
+}
@@ -0,0 +1,125 @@
+package deliver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOneBot 是本地 OneBot v11 HTTP 替身，只实现 send_group_msg，机器人不在 missing 中的群。
+type fakeOneBot struct {
+	token   string
+	missing map[int64]bool
+
+	mu       sync.Mutex
+	messages map[int64][]string
+}
+
+func (f *fakeOneBot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/send_group_msg" {
+		http.NotFound(w, r)
+		return
+	}
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		http.Error(w, `{"status":"failed","retcode":1403}`, http.StatusForbidden)
+		return
+	}
+	var msg onebotGroupMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil || !msg.AutoEscape {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if f.missing[msg.GroupID] {
+		fmt.Fprint(w, `{"status":"failed","retcode":100,"message":"GROUP_NOT_FOUND","wording":"群不存在"}`)
+		return
+	}
+	f.mu.Lock()
+	f.messages[msg.GroupID] = append(f.messages[msg.GroupID], msg.Message)
+	f.mu.Unlock()
+	fmt.Fprint(w, `{"status":"ok","retcode":0,"data":{"message_id":1}}`)
+}
+
+func testWeeklyDigest(courses int) Digest {
+	d := Digest{
+		Kind:    "weekly",
+		Title:   "AUTO 周报 2026-02-06 - 2026-02-13",
+		URL:     "https://hoa.moe/news/weekly/weekly-2026-02-13/",
+		Summary: "- **[电路原理](https://github.com/HITSZ-OpenAuto/EE3001)**：新增期末试卷 &amp; 答案\n- **计算机导论**：整理目录",
+	}
+	for i := 0; i < courses; i++ {
+		d.Courses = append(d.Courses, DigestCourse{Name: fmt.Sprintf("课程%02d", i), Commits: 2, Messages: []string{"上传 <实验报告>", strings.Repeat("补充", 30)}})
+	}
+	return d
+}
+
+func TestOneBot_Send(t *testing.T) {
+	fake := &fakeOneBot{token: "t0ken", missing: map[int64]bool{222: true}, messages: map[int64][]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	bot := newOneBot(server.URL+"/", "t0ken", []int64{111, 222, 333}, 400)
+	var sleeps []time.Duration
+	bot.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	err := bot.send(testWeeklyDigest(8))
+	if err == nil || !strings.Contains(err.Error(), "group 222") || !strings.Contains(err.Error(), "GROUP_NOT_FOUND") {
+		t.Errorf("expected error for the missing group, got %v", err)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	messages := fake.messages[111]
+	if len(messages) < 2 || strings.Join(messages, "\n") != strings.Join(fake.messages[333], "\n") {
+		t.Fatalf("groups 111 and 333 should receive the same split messages, got %d and %d", len(messages), len(fake.messages[333]))
+	}
+	for i, message := range messages {
+		if n := len([]rune(message)); n > 400 {
+			t.Errorf("message %d has %d characters, want at most 400", i, n)
+		}
+		if !strings.HasPrefix(message, fmt.Sprintf("(%d/%d) ", i+1, len(messages))) {
+			t.Errorf("message %d should start with its index: %q", i, message[:20])
+		}
+		// 只在课程之间拆分：每条消息中的课程段落都是完整的
+		if strings.Count(message, "【") != strings.Count(message, "条提交") {
+			t.Errorf("message %d splits a course section:\n%s", i, message)
+		}
+	}
+	first := messages[0]
+	for _, want := range []string{"本周更新摘要：\n· 电路原理：新增期末试卷 & 答案\n· 计算机导论：整理目录", "【课程00】2 条提交\n· 上传 <实验报告>"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("first message missing %q:\n%s", want, first)
+		}
+	}
+	if !strings.HasSuffix(messages[len(messages)-1], "完整报告：https://hoa.moe/news/weekly/weekly-2026-02-13/") {
+		t.Errorf("last message should end with the report link")
+	}
+	if len(sleeps) != 2*len(messages) {
+		t.Errorf("sleeps = %d, want one between consecutive messages", len(sleeps))
+	}
+
+	bot = newOneBot(server.URL, "wrong", []int64{111}, 400)
+	if err := bot.send(testWeeklyDigest(0)); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected error for a bad access token, got %v", err)
+	}
+}
+
+func TestSplitMessages(t *testing.T) {
+	qqIndex := func(i, n int) string { return fmt.Sprintf("(%d/%d) ", i, n) }
+	if got := splitMessages([]string{"标题", "正文"}, 100, qqIndex); len(got) != 1 || got[0] != "标题\n\n正文" {
+		t.Errorf("short digest should be a single message without index, got %q", got)
+	}
+	long := "【大课】1 条提交\n" + strings.Repeat("很长的一行", 50) + "\n短行"
+	got := splitMessages([]string{"标题", long}, 120, qqIndex)
+	for _, message := range got {
+		if n := len([]rune(message)); n > 120 {
+			t.Errorf("message has %d characters, want at most 120: %q", n, message)
+		}
+	}
+	if !strings.HasSuffix(got[len(got)-1], "短行") {
+		t.Errorf("oversized section should be split between lines, got %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+// Slack 推送：通过 incoming webhook 以 Block Kit 消息发送报告摘要
+package deliver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+// Block Kit 的长度限制，见 https://api.slack.com/reference/block-kit/blocks
+const (
+	slackMaxHeader  = 150
+	slackMaxSection = 3000
+	slackMaxBlocks  = 50
+)
+
+// slack 调用 incoming webhook，见 https://api.slack.com/messaging/webhooks
+type slack struct {
+	webhook    string
+	maxCourses int
+	client     *http.Client
+}
+
+func newSlack(webhook string, maxCourses int) *slack {
+	return &slack{webhook: webhook, maxCourses: maxCourses, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (s *slack) name() string { return "slack" }
+
+type slackMessage struct {
+	Text   string       `json:"text"` // 通知和不支持 Block Kit 的客户端中显示的文本
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackBlock 是一个布局块，按 Type 使用不同的字段。
+type slackBlock struct {
+	Type     string         `json:"type"` // header、section、context、divider、actions
+	Text     *slackText     `json:"text,omitempty"`
+	Elements []slackElement `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"` // plain_text 或 mrkdwn
+	Text string `json:"text"`
+}
+
+// slackElement 是 context 中的文本或 actions 中的按钮。
+type slackElement struct {
+	Type  string `json:"type"` // mrkdwn 或 button
+	Text  any    `json:"text"` // mrkdwn 为字符串，button 为 slackText
+	URL   string `json:"url,omitempty"`
+	Style string `json:"style,omitempty"`
+}
+
+// send 发送一条 Block Kit 消息，webhook 成功时返回 200 和文本 ok。
+func (s *slack) send(d Digest) error {
+	return postJSON(s.client, s.webhook, buildSlackMessage(d, s.maxCourses), nil)
+}
+
+// buildSlackMessage 构建消息：标题、摘要、日报统计、每门课程一节，最后是跳转按钮。
+func buildSlackMessage(d Digest, maxCourses int) slackMessage {
+	m := slackMarkup
+	title := cleanText(d.Title)
+	section := func(text string) slackBlock {
+		return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncateRunes(text, slackMaxSection)}}
+	}
+	context := func(text string) slackBlock {
+		return slackBlock{Type: "context", Elements: []slackElement{{Type: "mrkdwn", Text: text}}}
+	}
+
+	blocks := []slackBlock{{Type: "header", Text: &slackText{Type: "plain_text", Text: truncateRunes(title, slackMaxHeader)}}}
+	if d.Summary != "" {
+		blocks = append(blocks, section(renderMarkdown(d.Summary, m)))
+	}
+	if d.Kind == report.ReportKindDaily {
+		blocks = append(blocks, context(m.text(dailyStats(d))))
+	}
+	blocks = append(blocks, slackBlock{Type: "divider"})
+
+	// 其余块最多 3 个：省略说明、分隔线和按钮
+	courses, omitted := limitCourses(d.Courses, min(maxCourses, slackMaxBlocks-len(blocks)-3))
+	for _, course := range courses {
+		blocks = append(blocks, section(renderCourse(course, m)))
+	}
+	if omitted > 0 {
+		blocks = append(blocks, context(m.text(fmt.Sprintf("另有 %d 门课程有更新，详见完整报告。", omitted))))
+	}
+	if url, ok := linkURL(d.URL); ok {
+		blocks = append(blocks, slackBlock{Type: "actions", Elements: []slackElement{
+			{Type: "button", Text: slackText{Type: "plain_text", Text: "查看完整报告"}, URL: url, Style: "primary"},
+		}})
+	}
+	return slackMessage{Text: title, Blocks: blocks}
+}
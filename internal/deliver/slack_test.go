@@ -0,0 +1,63 @@
+package deliver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlack_Send(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, "invalid_payload", http.StatusBadRequest)
+			return
+		}
+		body = msg
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	d := newDigest(testDailyPublished(), "HITSZ-OpenAuto")
+	if err := newSlack(server.URL+"/services/T/B/secret", 8).send(d); err != nil {
+		t.Fatalf("send() returned error: %v", err)
+	}
+	var msg slackMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+	var types []string
+	for _, block := range msg.Blocks {
+		types = append(types, block.Type)
+	}
+	if got := strings.Join(types, ","); got != "header,context,divider,section,section,actions" {
+		t.Errorf("block types = %s", got)
+	}
+	if msg.Text != "AUTO 更新速递" {
+		t.Errorf("fallback text = %q", msg.Text)
+	}
+	for _, want := range []string{
+		`"text":"提交 4 条，新 Issue 1 个，新 PR 1 个"`,
+		`"text":"*\u003chttps://github.com/HITSZ-OpenAuto/EE3001|电路原理\u003e* · 3 条提交\n• 上传试卷\n• 修正答案"`,
+		`"url":"https://hoa.moe/news/daily/"`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("request missing %s:\n%s", want, body)
+		}
+	}
+}
+
+func TestBuildSlackMessage_Limits(t *testing.T) {
+	msg := buildSlackMessage(testWeeklyDigest(80), 80)
+	if len(msg.Blocks) > slackMaxBlocks {
+		t.Errorf("message has %d blocks", len(msg.Blocks))
+	}
+	last := msg.Blocks[len(msg.Blocks)-1]
+	if last.Type != "actions" || !strings.Contains(msg.Blocks[len(msg.Blocks)-2].Elements[0].Text.(string), "另有") {
+		t.Errorf("message should end with the omitted-courses note and the report button")
+	}
+}
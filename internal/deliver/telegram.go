@@ -0,0 +1,102 @@
+// Telegram 推送：通过 Bot API 的 sendMessage 以 MarkdownV2 格式发送报告摘要
+package deliver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/report"
+)
+
+const (
+	telegramAPI        = "https://api.telegram.org"
+	telegramMaxText    = 4096        // sendMessage 单条消息的最大长度
+	telegramMessageGap = time.Second // 同一会话中相邻两条消息的间隔，Bot API 限制每个会话约每秒一条
+)
+
+// telegram 调用 Bot API 的 sendMessage，见 https://core.telegram.org/bots/api#sendmessage
+type telegram struct {
+	apiURL     string
+	token      string
+	chatIDs    []string // 会话 ID 或 @频道名
+	maxCourses int
+	client     *http.Client
+	sleep      func(time.Duration)
+}
+
+func newTelegram(token string, chatIDs []string, maxCourses int) *telegram {
+	return &telegram{apiURL: telegramAPI, token: token, chatIDs: chatIDs, maxCourses: maxCourses, client: &http.Client{Timeout: httpTimeout}, sleep: time.Sleep}
+}
+
+func (t *telegram) name() string { return "telegram" }
+
+type telegramMessage struct {
+	ChatID             string                     `json:"chat_id"`
+	Text               string                     `json:"text"`
+	ParseMode          string                     `json:"parse_mode"`
+	LinkPreviewOptions telegramLinkPreviewOptions `json:"link_preview_options"`
+}
+
+type telegramLinkPreviewOptions struct {
+	IsDisabled bool `json:"is_disabled"`
+}
+
+// telegramResponse 是 Bot API 的响应。请求失败时 HTTP 状态码非 2xx，由 postJSON 返回错误。
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// send 将摘要发往每个会话，超过长度限制时在课程之间拆成多条。某个会话失败时继续发送其他会话。
+func (t *telegram) send(d Digest) error {
+	messages := splitMessages(telegramSections(d, t.maxCourses), telegramMaxText, func(i, n int) string {
+		return telegramMarkup.text(fmt.Sprintf("(%d/%d) ", i, n))
+	})
+	target := t.apiURL + "/bot" + t.token + "/sendMessage"
+	var errs []error
+	first := true
+	for _, chatID := range t.chatIDs {
+		for _, text := range messages {
+			if !first {
+				t.sleep(telegramMessageGap)
+			}
+			first = false
+			msg := telegramMessage{ChatID: chatID, Text: text, ParseMode: "MarkdownV2", LinkPreviewOptions: telegramLinkPreviewOptions{IsDisabled: true}}
+			var resp telegramResponse
+			if err := postJSON(t.client, target, msg, &resp); err != nil {
+				errs = append(errs, fmt.Errorf("chat %s: %w", chatID, err))
+				break
+			}
+			if !resp.OK {
+				errs = append(errs, fmt.Errorf("chat %s: %s", chatID, resp.Description))
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// telegramSections 返回 MarkdownV2 格式的段落：标题、摘要或日报统计、每门课程一段，最后是报告链接。
+func telegramSections(d Digest, maxCourses int) []string {
+	m := telegramMarkup
+	sections := []string{m.bold(m.text(cleanText(d.Title)))}
+	if d.Summary != "" {
+		sections = append(sections, renderMarkdown(d.Summary, m))
+	}
+	if d.Kind == report.ReportKindDaily {
+		sections = append(sections, m.text(dailyStats(d)))
+	}
+	courses, omitted := limitCourses(d.Courses, maxCourses)
+	for _, course := range courses {
+		sections = append(sections, renderCourse(course, m))
+	}
+	if omitted > 0 {
+		sections = append(sections, m.text(fmt.Sprintf("另有 %d 门课程有更新。", omitted)))
+	}
+	if url, ok := linkURL(d.URL); ok {
+		sections = append(sections, m.link("查看完整报告", url))
+	}
+	return sections
+}
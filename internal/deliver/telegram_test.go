@@ -0,0 +1,126 @@
+package deliver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTelegram_Send(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]telegramMessage{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:abc/sendMessage" {
+			http.NotFound(w, r)
+			return
+		}
+		var msg telegramMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if msg.ChatID == "@missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`)
+			return
+		}
+		mu.Lock()
+		received[msg.ChatID] = append(received[msg.ChatID], msg)
+		mu.Unlock()
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}))
+	defer server.Close()
+
+	bot := newTelegram("123:abc", []string{"-100123", "@missing"}, 8)
+	bot.apiURL = server.URL
+	bot.sleep = func(time.Duration) {}
+
+	err := bot.send(testWeeklyDigest(3))
+	if err == nil || !strings.Contains(err.Error(), "chat @missing") {
+		t.Errorf("expected error for the missing chat, got %v", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "123:abc") {
+		t.Errorf("error leaks the bot token: %v", err)
+	}
+	messages := received["-100123"]
+	if len(messages) != 1 {
+		t.Fatalf("chat -100123 received %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.ParseMode != "MarkdownV2" || !msg.LinkPreviewOptions.IsDisabled {
+		t.Errorf("message options = %+v", msg)
+	}
+	for _, want := range []string{
+		"*AUTO 周报 2026\\-02\\-06 \\- 2026\\-02\\-13*",
+		"• *[电路原理](https://github.com/HITSZ-OpenAuto/EE3001)*：新增期末试卷 & 答案",
+		"*课程00* · 2 条提交\n• 上传 <实验报告\\>",
+		"[查看完整报告](https://hoa.moe/news/weekly/weekly-2026-02-13/)",
+	} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("message missing %q:\n%s", want, msg.Text)
+		}
+	}
+}
+
+func TestTelegram_SplitsLongDigest(t *testing.T) {
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg telegramMessage
+		json.NewDecoder(r.Body).Decode(&msg)
+		texts = append(texts, msg.Text)
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	bot := newTelegram("123:abc", []string{"1"}, 100)
+	bot.apiURL = server.URL
+	var sleeps int
+	bot.sleep = func(time.Duration) { sleeps++ }
+	d := testWeeklyDigest(60)
+	d.Summary = "- **" + strings.Repeat("长.", 3000) + "**"
+	for i := range d.Courses {
+		d.Courses[i].Messages = []string{strings.Repeat("长", 200), strings.Repeat(".", 5000)}
+	}
+	if err := bot.send(d); err != nil {
+		t.Fatalf("send() returned error: %v", err)
+	}
+	if len(texts) < 2 || sleeps != len(texts)-1 {
+		t.Fatalf("sent %d messages with %d sleeps, want a split digest paced between messages", len(texts), sleeps)
+	}
+	for i, text := range texts {
+		if n := len([]rune(text)); n > telegramMaxText {
+			t.Errorf("message %d has %d characters", i, n)
+		}
+		if prefix := fmt.Sprintf("\\(%d/%d\\) ", i+1, len(texts)); !strings.HasPrefix(text, prefix) {
+			t.Errorf("message %d should start with %q", i, prefix)
+		}
+		if err := checkMarkdownV2(text); err != nil {
+			t.Errorf("message %d is not valid MarkdownV2: %v", i, err)
+		}
+	}
+}
+
+// checkMarkdownV2 检查反斜杠只用于转义特殊字符，且未转义的 * 成对出现。
+func checkMarkdownV2(text string) error {
+	bold := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if i+1 >= len(text) || !strings.ContainsRune("_*[]()~`>#+-=|{}.!\\", rune(text[i+1])) {
+				return fmt.Errorf("invalid escape at byte %d", i)
+			}
+			i++
+		case '*':
+			bold++
+		}
+	}
+	if bold%2 != 0 {
+		return fmt.Errorf("unbalanced bold markers")
+	}
+	return nil
+}
@@ -3,13 +3,16 @@ package github
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
 )
@@ -20,6 +23,7 @@ type Label struct {
 
 type Author struct {
 	Login string `json:"login"`
+	Type  string `json:"type"` // 账号类型，如 User、Bot
 }
 
 type Repository struct {
@@ -41,18 +45,47 @@ type Repo struct {
 }
 
 type Commit struct {
-	Commit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
 		Author struct {
-			Name string `json:"name"`
-			Date string `json:"date"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+			Date  string `json:"date"`
 		} `json:"author"`
 		Message string `json:"message"`
 	} `json:"commit"`
 	Author *struct {
 		Login string `json:"login"`
+		Type  string `json:"type"` // 账号类型，如 User、Bot
 	} `json:"author"`
 }
 
+// PullRequest 是 pull request API 响应中用到的字段。
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	User    Author `json:"user"`
+}
+
+// CommitSearchResult 是 commit 搜索 API 的响应。
+type CommitSearchResult struct {
+	TotalCount int `json:"total_count"`
+	Items      []struct {
+		Commit struct {
+			Author struct {
+				Name string `json:"name"`
+				Date string `json:"date"`
+			} `json:"author"`
+			Message string `json:"message"`
+		} `json:"commit"`
+		Repository Repository `json:"repository"`
+	} `json:"items"`
+}
+
+// ghCommand 执行 gh 命令并返回标准输出，出错时错误信息为标准错误的内容，
+// 同时返回已输出的内容（如 --include 时的响应头）。
 func ghCommand(args []string) ([]byte, error) {
 	cmd := exec.Command("gh", args...)
 	cmd.Env = os.Environ()
@@ -61,7 +94,7 @@ func ghCommand(args []string) ([]byte, error) {
 	cmd.Stderr = &stderr
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, errors.New(stderr.String())
+		return output, errors.New(stderr.String())
 	}
 	return output, nil
 }
@@ -130,19 +163,195 @@ func ListCommitsSince(orgName, repoName, since_RFC3339 string) ([]Commit, error)
 	return parseNDJSON[Commit](output)
 }
 
-// GetRawReadmeToml 从指定组织和仓库的根目录下获取 readme.toml 文件的内容。
-func GetRawReadmeToml(orgName, repoName string) (string, error) {
+// ListCommits 返回指定组织、指定仓库的全部提交历史。
+func ListCommits(orgName, repoName string) ([]Commit, error) {
+	args := []string{
+		"api",
+		fmt.Sprintf("/repos/%s/%s/commits", orgName, repoName),
+		"--method", "GET",
+		"--paginate",
+		"-f", "per_page=100",
+		"--jq",
+		".[]",
+	}
+	output, err := ghCommand(args)
+	if err != nil {
+		return nil, err
+	}
+	return parseNDJSON[Commit](output)
+}
+
+// SearchEarliestCommit 在指定组织内搜索满足 query 的 commit，按作者日期升序只返回最早的一条。
+// query 使用 GitHub commit 搜索语法，例如 "author:octocat author-date:<2024-01-01"。
+// 触发限流时返回 *RateLimitError。
+func SearchEarliestCommit(orgName, query string) (CommitSearchResult, error) {
+	args := []string{
+		"api",
+		"/search/commits",
+		"--method", "GET",
+		"--include",
+		"-f", fmt.Sprintf("q=org:%s %s", orgName, query),
+		"-f", "sort=author-date",
+		"-f", "order=asc",
+		"-f", "per_page=1",
+	}
+	var result CommitSearchResult
+	output, err := ghCommand(args)
+	header, body := splitIncludedResponse(output)
+	if err != nil {
+		if limited := rateLimitError(err, header, time.Now()); limited != nil {
+			return result, limited
+		}
+		return result, err
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// RateLimitError 表示请求因 API 限流被拒绝（HTTP 429，或额度耗尽的 HTTP 403）。
+type RateLimitError struct {
+	RetryAfter time.Duration // 响应头建议的等待时间，未知时为 0
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited (retry after %s): %s", e.RetryAfter, strings.TrimSpace(e.Message))
+}
+
+// splitIncludedResponse 拆分 gh api --include 的输出，返回响应头和响应体。
+func splitIncludedResponse(output []byte) (http.Header, []byte) {
+	text := strings.ReplaceAll(string(output), "\r\n", "\n")
+	head, body, _ := strings.Cut(text, "\n\n")
+	header := make(http.Header)
+	for _, line := range strings.Split(head, "\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+	return header, []byte(body)
+}
+
+// rateLimitError 根据 gh 的错误信息和响应头判断请求是否被限流，不是限流时返回 nil。
+// 等待时间优先取 Retry-After，其次取 X-RateLimit-Reset 距 now 的时间。
+func rateLimitError(err error, header http.Header, now time.Time) *RateLimitError {
+	msg := err.Error()
+	limited := strings.Contains(msg, "HTTP 429") ||
+		strings.Contains(msg, "HTTP 403") && (header.Get("Retry-After") != "" || header.Get("X-RateLimit-Remaining") == "0" || strings.Contains(strings.ToLower(msg), "rate limit"))
+	if !limited {
+		return nil
+	}
+	e := &RateLimitError{Message: msg}
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	} else if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		e.RetryAfter = max(time.Unix(reset, 0).Sub(now), 0)
+	}
+	return e
+}
+
+// GetCommitFiles 返回指定 commit 变更的文件路径列表。
+func GetCommitFiles(orgName, repoName, sha string) ([]string, error) {
+	args := []string{
+		"api",
+		fmt.Sprintf("/repos/%s/%s/commits/%s", orgName, repoName, sha),
+		"--jq",
+		".files[].filename",
+	}
+	output, err := ghCommand(args)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0)
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// GetPullRequest 返回指定仓库中编号为 number 的 pull request。
+func GetPullRequest(orgName, repoName string, number int) (PullRequest, error) {
+	args := []string{
+		"api",
+		fmt.Sprintf("/repos/%s/%s/pulls/%d", orgName, repoName, number),
+	}
+	var pr PullRequest
+	output, err := ghCommand(args)
+	if err != nil {
+		return pr, err
+	}
+	if err := json.Unmarshal(output, &pr); err != nil {
+		return pr, err
+	}
+	return pr, nil
+}
+
+// ErrNotModified 表示条件请求命中，资源自上次获取后没有变化。
+var ErrNotModified = errors.New("not modified")
+
+// ReadmeToml 是仓库根目录下 readme.toml 的内容及其版本信息。
+type ReadmeToml struct {
+	SHA     string // blob SHA
+	ETag    string // 用于下次条件请求
+	Content string
+}
+
+// GetReadmeToml 获取指定仓库根目录下的 readme.toml。etag 非空时发起条件请求，
+// 文件未变化时返回 ErrNotModified，条件请求命中不计入 API 限流额度。
+func GetReadmeToml(orgName, repoName, etag string) (ReadmeToml, error) {
 	args := []string{
 		"api",
 		fmt.Sprintf("/repos/%s/%s/contents/readme.toml", orgName, repoName),
-		"-H",
-		"Accept: application/vnd.github.raw",
+		"--include",
+	}
+	if etag != "" {
+		args = append(args, "-H", "If-None-Match: "+etag)
 	}
 	output, err := ghCommand(args)
 	if err != nil {
-		return "", err
+		if strings.Contains(err.Error(), "HTTP 304") {
+			return ReadmeToml{}, ErrNotModified
+		}
+		return ReadmeToml{}, err
+	}
+	return parseReadmeTomlResponse(output)
+}
+
+// parseReadmeTomlResponse 解析 gh api --include 的输出：状态行和响应头，空行，JSON 响应体。
+func parseReadmeTomlResponse(output []byte) (ReadmeToml, error) {
+	text := strings.ReplaceAll(string(output), "\r\n", "\n")
+	head, body, found := strings.Cut(text, "\n\n")
+	if !found {
+		return ReadmeToml{}, fmt.Errorf("unexpected response without headers")
+	}
+	var file ReadmeToml
+	for _, line := range strings.Split(head, "\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "ETag") {
+			file.ETag = strings.TrimSpace(value)
+		}
+	}
+
+	var content struct {
+		SHA      string `json:"sha"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := json.Unmarshal([]byte(body), &content); err != nil {
+		return ReadmeToml{}, err
+	}
+	if content.Encoding != "base64" {
+		return ReadmeToml{}, fmt.Errorf("unexpected content encoding %q", content.Encoding)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return ReadmeToml{}, err
 	}
-	return string(output), nil
+	file.SHA = content.SHA
+	file.Content = string(decoded)
+	return file, nil
 }
 
 // LoadPublicRepos 从远程 repos_list.txt 获取公开仓库名称集合。
@@ -0,0 +1,120 @@
+package i18n
+
+// catalogs 是各语言的消息目录，键按报告中出现的位置分组。
+// 带格式占位符的条目由 Lang.T 按 fmt.Sprintf 填充，各语言中占位符的数量和顺序必须一致。
+var catalogs = map[Lang]map[string]string{
+	ZH: {
+		"lang.tag":       "zh-CN", // BCP 47 语言标签
+		"list.separator": "、",
+
+		"category.material":    "新增资料",
+		"category.correction":  "勘误修正",
+		"category.docs":        "课程信息与文档",
+		"category.review":      "教师评价",
+		"category.restructure": "结构调整",
+		"category.trivial":     "日常维护",
+		"category.other":       "其他更新",
+
+		"commit.line":          "%s 在 %s 中提交了信息：%s",
+		"commit.short":         "%s：%s",
+		"commit.batch":         "（共 %d 次提交）",
+		"commit.trivial":       "共 %d 条日常维护提交，涉及 %s",
+		"commit.trivial.count": "共 %d 条日常维护提交",
+		"location.all":         "%s 共 %d 门课程",
+		"location.more":        "%s 等 %d 门课程",
+		"location.details":     "查看全部 %d 门课程",
+
+		"daily.title":        "AUTO 更新速递",
+		"daily.description":  "每日更新",
+		"daily.recent":       "最近更新",
+		"daily.empty":        "暂无更新",
+		"daily.batches":      "跨课程批量操作",
+		"daily.automated":    "另有 %d 条自动化操作提交未列出。",
+		"daily.issues":       "待解决的 Issues",
+		"daily.issues.empty": "暂无待解决的 Issues",
+		"daily.prs":          "待合并的 Pull Requests",
+		"daily.prs.empty":    "暂无待合并的 Pull Requests",
+		"item.repo":          "仓库",
+		"item.created":       "创建于",
+		"item.author":        "作者",
+		"item.labels":        "标签",
+
+		"weekly.title":             "AUTO 周报 %s - %s",
+		"weekly.description":       "涵盖 %s 至 %s 的更新",
+		"weekly.index.title":       "AUTO 周报",
+		"weekly.index.description": "AUTO 周报是由 ChatGPT 每周五发布的一份简报，最近更新于 %s。",
+		"weekly.summary":           "本周更新摘要",
+		"weekly.updates":           "更新内容",
+		"weekly.commits":           "（%d 条提交）",
+		"weekly.batches":           "跨课程批量操作（%d 条）",
+
+		"stats.title":        "本周统计",
+		"stats.commits":      "提交数",
+		"stats.contributors": "贡献者",
+		"stats.courses":      "涉及课程",
+		"stats.first_timers": "新贡献者",
+		"stats.automated":    "自动化操作",
+
+		"firsttimers.title": "欢迎新贡献者",
+		"firsttimers.intro": "本周有以下同学完成了在 %s 的第一次贡献，欢迎加入！",
+		"firsttimers.entry": "%s 在 %s 中完成了第一次贡献：%s (%s)",
+		"firsttimers.total": "，本周共提交 %d 次",
+	},
+	EN: {
+		"lang.tag":       "en",
+		"list.separator": ", ",
+
+		"category.material":    "New Materials",
+		"category.correction":  "Corrections",
+		"category.docs":        "Course Info and Docs",
+		"category.review":      "Course Reviews",
+		"category.restructure": "Restructuring",
+		"category.trivial":     "Maintenance",
+		"category.other":       "Other Updates",
+
+		"commit.line":          "%s committed to %s: %s",
+		"commit.short":         "%s: %s",
+		"commit.batch":         " (%d commits)",
+		"commit.trivial":       "%d maintenance commits in %s",
+		"commit.trivial.count": "%d maintenance commits",
+		"location.all":         "%s (%d courses)",
+		"location.more":        "%s and more (%d courses)",
+		"location.details":     "Show all %d courses",
+
+		"daily.title":        "AUTO Daily Updates",
+		"daily.description":  "Daily updates",
+		"daily.recent":       "Recent Updates",
+		"daily.empty":        "No updates",
+		"daily.batches":      "Cross-course Batch Operations",
+		"daily.automated":    "%d more automated commits are not listed.",
+		"daily.issues":       "Open Issues",
+		"daily.issues.empty": "No open issues",
+		"daily.prs":          "Open Pull Requests",
+		"daily.prs.empty":    "No open pull requests",
+		"item.repo":          "Repository",
+		"item.created":       "Created",
+		"item.author":        "Author",
+		"item.labels":        "Labels",
+
+		"weekly.title":             "AUTO Weekly %s - %s",
+		"weekly.description":       "Updates from %s to %s",
+		"weekly.index.title":       "AUTO Weekly",
+		"weekly.index.description": "AUTO Weekly is a brief published by ChatGPT every Friday, last updated on %s.",
+		"weekly.summary":           "Weekly Summary",
+		"weekly.updates":           "Updates",
+		"weekly.commits":           " (%d commits)",
+		"weekly.batches":           "Cross-course Batch Operations (%d)",
+
+		"stats.title":        "This Week in Numbers",
+		"stats.commits":      "Commits",
+		"stats.contributors": "Contributors",
+		"stats.courses":      "Courses",
+		"stats.first_timers": "New contributors",
+		"stats.automated":    "Automated commits",
+
+		"firsttimers.title": "Welcome, New Contributors",
+		"firsttimers.intro": "The following people made their first contribution to %s this week. Welcome aboard!",
+		"firsttimers.entry": "%s made their first contribution to %s: %s (%s)",
+		"firsttimers.total": ", %d commits this week",
+	},
+}
@@ -0,0 +1,99 @@
+// 报告文本的多语言支持：消息目录、日期和星期的本地化格式，以及译文页面的路径约定
+package i18n
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// Lang 是报告语言。
+type Lang string
+
+const (
+	ZH Lang = "zh" // 简体中文，默认语言
+	EN Lang = "en" // 英文
+)
+
+// Default 是默认语言，其页面不带语言后缀。
+const Default = ZH
+
+// Parse 解析语言代码，不区分大小写，如 "en"、"ZH"。
+func Parse(code string) (Lang, error) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(code)))
+	if _, ok := catalogs[lang]; !ok {
+		return "", fmt.Errorf("unsupported language %q", code)
+	}
+	return lang, nil
+}
+
+// ParseList 解析逗号分隔的语言列表，如 "zh,en"，去除重复项。
+func ParseList(codes string) ([]Lang, error) {
+	var langs []Lang
+	seen := make(map[Lang]bool)
+	for _, code := range strings.Split(codes, ",") {
+		lang, err := Parse(code)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[lang] {
+			seen[lang] = true
+			langs = append(langs, lang)
+		}
+	}
+	return langs, nil
+}
+
+// T 返回 key 对应的文本，args 按 fmt.Sprintf 填入。
+// 当前语言缺少该条目时回退到默认语言，仍缺失时返回 key 本身，便于发现遗漏。
+func (l Lang) T(key string, args ...any) string {
+	format, ok := catalogs[l][key]
+	if !ok {
+		if format, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Tag 返回语言的 BCP 47 标签，如 zh-CN 或 en，用于订阅源等需要标注语言的输出。
+func (l Lang) Tag() string {
+	return l.T("lang.tag")
+}
+
+// Weekday 返回 t 是星期几，如「周二」或 Tue。
+func (l Lang) Weekday(t time.Time) string {
+	if l == EN {
+		return t.Weekday().String()[:3]
+	}
+	return utils.ChineseWeekday(t)
+}
+
+// ShortDate 返回不带年份的日期，如 2.10 或 Feb 10。
+func (l Lang) ShortDate(t time.Time) string {
+	if l == EN {
+		return t.Format("Jan 2")
+	}
+	return fmt.Sprintf("%d.%d", t.Month(), t.Day())
+}
+
+// List 用当前语言的分隔符连接列表项，如「A、B」或「A, B」。
+func (l Lang) List(items []string) string {
+	return strings.Join(items, l.T("list.separator"))
+}
+
+// Path 返回 path 对应语言的页面路径：默认语言不变，其他语言在扩展名前插入语言代码，
+// 如 news/daily.md -> news/daily.en.md，与 Hugo 按文件名区分语言的约定一致。
+func (l Lang) Path(path string) string {
+	if l == Default || l == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + string(l) + ext
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	langs, err := ParseList(" EN,zh,en")
+	if err != nil {
+		t.Fatalf("ParseList() returned error: %v", err)
+	}
+	if len(langs) != 2 || langs[0] != EN || langs[1] != ZH {
+		t.Errorf("ParseList() = %v, want [en zh]", langs)
+	}
+	if _, err := ParseList("zh,fr"); err == nil {
+		t.Errorf("expected error for unsupported language")
+	}
+}
+
+func TestLang_T(t *testing.T) {
+	if got := EN.T("commit.batch", 3); got != " (3 commits)" {
+		t.Errorf("EN.T() = %q", got)
+	}
+	if got := ZH.T("commit.batch", 3); got != "（共 3 次提交）" {
+		t.Errorf("ZH.T() = %q", got)
+	}
+	if got := Lang("fr").T("daily.empty"); got != "暂无更新" {
+		t.Errorf("unknown language should fall back to the default catalog, got %q", got)
+	}
+	if ZH.Tag() != "zh-CN" || EN.Tag() != "en" {
+		t.Errorf("unexpected language tags %q, %q", ZH.Tag(), EN.Tag())
+	}
+	if got := EN.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key should be returned as is, got %q", got)
+	}
+}
+
+// 各语言的条目应与默认语言一一对应，且占位符一致，避免 Sprintf 输出 %!d 之类的错误。
+func TestCatalogsMatchDefault(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z]`)
+	for lang, catalog := range catalogs {
+		for key, format := range catalogs[Default] {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			if got, want := verbs.FindAllString(translated, -1), verbs.FindAllString(format, -1); len(got) != len(want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("%s: unknown key %q", lang, key)
+			}
+		}
+	}
+}
+
+func TestLang_Dates(t *testing.T) {
+	date := time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC) // 周二
+	if got := ZH.Weekday(date) + " " + ZH.ShortDate(date); got != "周二 2.10" {
+		t.Errorf("ZH dates = %q", got)
+	}
+	if got := EN.Weekday(date) + " " + EN.ShortDate(date); got != "Tue Feb 10" {
+		t.Errorf("EN dates = %q", got)
+	}
+}
+
+func TestLang_Path(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		path string
+		want string
+	}{
+		{ZH, "news/daily.md", "news/daily.md"},
+		{EN, "news/daily.md", "news/daily.en.md"},
+		{EN, "news/weekly/weekly-2026-02-08/index.md", "news/weekly/weekly-2026-02-08/index.en.md"},
+	}
+	for _, tt := range tests {
+		if got := tt.lang.Path(tt.path); got != tt.want {
+			t.Errorf("%s.Path(%q) = %q, want %q", tt.lang, tt.path, got, tt.want)
+		}
+	}
+}
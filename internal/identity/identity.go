@@ -0,0 +1,140 @@
+// 贡献者身份解析：将不同的作者名、邮箱和登录名归并为同一个规范身份
+package identity
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Alias 描述一个规范身份及其所有已知别名，对应别名文件中的一项。
+type Alias struct {
+	Name   string   `yaml:"name"`   // 展示名
+	Login  string   `yaml:"login"`  // GitHub 登录名
+	Avatar string   `yaml:"avatar"` // 头像地址，省略时使用 GitHub 头像
+	Names  []string `yaml:"names"`  // 提交时可能使用的作者名
+	Emails []string `yaml:"emails"` // 提交时可能使用的邮箱
+}
+
+// Identity 是解析后的贡献者身份。
+type Identity struct {
+	Name   string
+	Login  string
+	Avatar string
+}
+
+// ProfileURL 返回 GitHub 个人主页地址，没有登录名时返回空字符串。
+func (id Identity) ProfileURL() string {
+	if id.Login == "" {
+		return ""
+	}
+	return "https://github.com/" + id.Login
+}
+
+// AvatarURL 返回头像地址，未指定时回退为 GitHub 头像，没有登录名时返回空字符串。
+func (id Identity) AvatarURL() string {
+	if id.Avatar != "" {
+		return id.Avatar
+	}
+	if id.Login == "" {
+		return ""
+	}
+	return "https://github.com/" + id.Login + ".png"
+}
+
+// Resolver 根据别名表解析提交作者的规范身份。nil Resolver 不做任何归并。
+type Resolver struct {
+	byEmail map[string]*Alias
+	byLogin map[string]*Alias
+	byName  map[string]*Alias
+}
+
+// LoadAliases 读取 YAML 格式的别名文件。
+func LoadAliases(path string) ([]Alias, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aliases %q: %w", path, err)
+	}
+	var aliases []Alias
+	if err := yaml.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases %q: %w", path, err)
+	}
+	for i, alias := range aliases {
+		if alias.Name == "" && alias.Login == "" {
+			return nil, fmt.Errorf("alias #%d in %q has neither name nor login", i+1, path)
+		}
+	}
+	return aliases, nil
+}
+
+// NewResolver 根据别名表构建 Resolver。名称、邮箱和登录名均不区分大小写。
+func NewResolver(aliases []Alias) *Resolver {
+	r := &Resolver{
+		byEmail: make(map[string]*Alias),
+		byLogin: make(map[string]*Alias),
+		byName:  make(map[string]*Alias),
+	}
+	for i := range aliases {
+		alias := &aliases[i]
+		for _, email := range alias.Emails {
+			r.byEmail[normalize(email)] = alias
+		}
+		if alias.Login != "" {
+			r.byLogin[normalize(alias.Login)] = alias
+		}
+		if alias.Name != "" {
+			r.byName[normalize(alias.Name)] = alias
+		}
+		for _, name := range alias.Names {
+			r.byName[normalize(name)] = alias
+		}
+	}
+	return r
+}
+
+// Resolve 按邮箱、登录名、作者名的优先级查找别名，返回规范身份。
+// 未命中别名时原样返回提交中的作者名和登录名。
+func (r *Resolver) Resolve(name, email, login string) Identity {
+	id := Identity{Name: strings.TrimSpace(name), Login: strings.TrimSpace(login)}
+	if r == nil {
+		return id
+	}
+
+	alias := r.lookup(email, login, name)
+	if alias == nil {
+		return id
+	}
+	if alias.Name != "" {
+		id.Name = alias.Name
+	}
+	if alias.Login != "" {
+		id.Login = alias.Login
+	}
+	id.Avatar = alias.Avatar
+	return id
+}
+
+func (r *Resolver) lookup(email, login, name string) *Alias {
+	if email != "" {
+		if alias, ok := r.byEmail[normalize(email)]; ok {
+			return alias
+		}
+	}
+	if login != "" {
+		if alias, ok := r.byLogin[normalize(login)]; ok {
+			return alias
+		}
+	}
+	if name != "" {
+		if alias, ok := r.byName[normalize(name)]; ok {
+			return alias
+		}
+	}
+	return nil
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
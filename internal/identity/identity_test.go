@@ -0,0 +1,88 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	r := NewResolver([]Alias{
+		{
+			Name:   "张三",
+			Login:  "zhangsan",
+			Names:  []string{"zs", "Zhang San"},
+			Emails: []string{"ZS@example.com"},
+		},
+		{
+			Name:   "李四",
+			Avatar: "https://example.com/lisi.png",
+			Names:  []string{"lisi-laptop"},
+		},
+	})
+
+	tests := []struct {
+		name               string
+		author, email, lgn string
+		want               Identity
+	}{
+		{"ByEmail", "whatever", "zs@example.com", "", Identity{Name: "张三", Login: "zhangsan"}},
+		{"ByName", "  zhang san ", "", "", Identity{Name: "张三", Login: "zhangsan"}},
+		{"ByLogin", "Someone", "", "ZhangSan", Identity{Name: "张三", Login: "zhangsan"}},
+		{"KeepsCommitLoginWhenAliasHasNone", "lisi-laptop", "", "lisi", Identity{Name: "李四", Login: "lisi", Avatar: "https://example.com/lisi.png"}},
+		{"Unmatched", "王五", "ww@example.com", "wangwu", Identity{Name: "王五", Login: "wangwu"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Resolve(tt.author, tt.email, tt.lgn); got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_Nil(t *testing.T) {
+	var r *Resolver
+	got := r.Resolve(" 张三 ", "zs@example.com", "zhangsan")
+	if got != (Identity{Name: "张三", Login: "zhangsan"}) {
+		t.Errorf("nil Resolver should pass through, got %+v", got)
+	}
+}
+
+func TestIdentity_URLs(t *testing.T) {
+	id := Identity{Name: "张三", Login: "zhangsan"}
+	if got := id.ProfileURL(); got != "https://github.com/zhangsan" {
+		t.Errorf("ProfileURL() = %q", got)
+	}
+	if got := id.AvatarURL(); got != "https://github.com/zhangsan.png" {
+		t.Errorf("AvatarURL() = %q", got)
+	}
+	anon := Identity{Name: "匿名"}
+	if anon.ProfileURL() != "" || anon.AvatarURL() != "" {
+		t.Errorf("expected empty URLs without login, got %q %q", anon.ProfileURL(), anon.AvatarURL())
+	}
+}
+
+func TestLoadAliases(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aliases.yaml")
+	content := "- name: 张三\n  login: zhangsan\n  names: [zs]\n  emails: [zs@example.com]\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write aliases: %v", err)
+	}
+	aliases, err := LoadAliases(path)
+	if err != nil {
+		t.Fatalf("LoadAliases() returned error: %v", err)
+	}
+	if len(aliases) != 1 || aliases[0].Login != "zhangsan" || aliases[0].Names[0] != "zs" {
+		t.Errorf("unexpected aliases: %+v", aliases)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("- names: [zs]\n"), 0o644); err != nil {
+		t.Fatalf("failed to write aliases: %v", err)
+	}
+	if _, err := LoadAliases(invalid); err == nil {
+		t.Errorf("expected error for alias without name or login")
+	}
+}
@@ -0,0 +1,236 @@
+// 提交信息的规范化与过滤
+package message
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// 可在 Config.Strip 中启用的规范化步骤。
+const (
+	StripConventional = "conventional" // 去掉 feat:、fix(scope): 等约定式提交类型
+	StripTags         = "tags"         // 去掉 [EE3001]、【勘误】等开头的方括号标签
+	StripEmoji        = "emoji"        // 去掉开头的 emoji 和 :sparkles: 形式的 gitmoji
+)
+
+// 过滤结果中使用的规则名。
+const (
+	RuleEmpty     = "empty"
+	RuleAllow     = "allow"
+	RuleDeny      = "deny"
+	RuleGenerated = "generated"
+	RuleLanguage  = "language"
+)
+
+// scripts 是 Config.Languages 支持的语言及其对应的文字。
+var scripts = map[string]*unicode.RangeTable{
+	"zh": unicode.Han,
+	"en": unicode.Latin,
+}
+
+var (
+	conventionalPrefix = regexp.MustCompile(`(?i)^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([^)]*\))?!?\s*[:：]\s*`)
+	tagPrefix          = regexp.MustCompile(`^(\[[^\]]*\]|【[^】]*】)\s*`)
+	gitmojiPrefix      = regexp.MustCompile(`^:[a-z0-9_+-]+:\s*`)
+)
+
+// DefaultGenerated 是内置的自动生成提交信息模式，主要来自 GitHub 网页端的默认提交信息和无信息量的单词。
+var DefaultGenerated = []string{
+	`(?i)^(update|create|delete) [^\s]+$`,
+	`(?i)^rename [^\s]+ to [^\s]+$`,
+	`(?i)^add files via upload$`,
+	`(?i)^initial commit$`,
+	`(?i)^merge (remote-tracking )?branch `,
+	`(?i)^merge pull request #\d+`,
+	`(?i)^(update|updates|fix|wip|test|init|commit|修改|更新|提交|上传)\.?$`,
+}
+
+// Config 配置提交信息的规范化和过滤规则，对应配置文件中的 messages 段。
+type Config struct {
+	Strip     []string `yaml:"strip"`     // 启用的规范化步骤：conventional、tags、emoji
+	Languages []string `yaml:"languages"` // 允许的语言：zh、en，留空表示不检查语言
+	MinRatio  float64  `yaml:"min_ratio"` // 允许语言的字符占全部字母类字符的最低比例
+	Allow     []string `yaml:"allow"`     // 命中即保留的正则，优先于其他规则
+	Deny      []string `yaml:"deny"`      // 命中即丢弃的正则
+	Generated []string `yaml:"generated"` // 额外的自动生成提交信息正则，与 DefaultGenerated 一起生效
+}
+
+// Decision 是过滤结果。Keep 为 false 时 Rule 和 Reason 说明丢弃的原因。
+type Decision struct {
+	Keep   bool
+	Rule   string
+	Reason string
+}
+
+// Rule 是一条过滤规则，对规范化后的首行做出判断。decided 为 false 表示交给后续规则。
+type Rule interface {
+	Check(subject string) (d Decision, decided bool)
+}
+
+// Filter 按配置规范化提交信息，并依次应用过滤规则。
+type Filter struct {
+	strip map[string]bool
+	rules []Rule
+}
+
+// New 根据配置构建 Filter，正则无效或语言未知时返回错误。
+// 规则顺序为：allow、deny、自动生成检测、语言比例，全部未决定时保留。
+func New(cfg Config) (*Filter, error) {
+	f := &Filter{strip: make(map[string]bool)}
+	for _, step := range cfg.Strip {
+		switch step {
+		case StripConventional, StripTags, StripEmoji:
+			f.strip[step] = true
+		default:
+			return nil, fmt.Errorf("unknown strip step %q", step)
+		}
+	}
+
+	allow, err := compileAll(cfg.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allow pattern: %w", err)
+	}
+	deny, err := compileAll(cfg.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deny pattern: %w", err)
+	}
+	generated, err := compileAll(append(append([]string{}, DefaultGenerated...), cfg.Generated...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated pattern: %w", err)
+	}
+	f.rules = append(f.rules,
+		patternRule{rule: RuleAllow, keep: true, patterns: allow},
+		patternRule{rule: RuleDeny, patterns: deny},
+		patternRule{rule: RuleGenerated, patterns: generated},
+	)
+
+	if len(cfg.Languages) > 0 {
+		tables := make([]*unicode.RangeTable, 0, len(cfg.Languages))
+		for _, lang := range cfg.Languages {
+			table, ok := scripts[lang]
+			if !ok {
+				return nil, fmt.Errorf("unknown language %q", lang)
+			}
+			tables = append(tables, table)
+		}
+		if cfg.MinRatio < 0 || cfg.MinRatio > 1 {
+			return nil, fmt.Errorf("min_ratio %v out of range [0, 1]", cfg.MinRatio)
+		}
+		f.rules = append(f.rules, languageRule{tables: tables, minRatio: cfg.MinRatio})
+	}
+	return f, nil
+}
+
+// Normalize 规范化提交信息：对首行反复去掉启用的前缀直到不再变化，其余行原样保留。
+func (f *Filter) Normalize(message string) string {
+	subject, rest, hasRest := strings.Cut(message, "\n")
+	subject = strings.TrimSpace(subject)
+	for {
+		prev := subject
+		if f.strip[StripConventional] {
+			subject = conventionalPrefix.ReplaceAllString(subject, "")
+		}
+		if f.strip[StripTags] {
+			subject = tagPrefix.ReplaceAllString(subject, "")
+		}
+		if f.strip[StripEmoji] {
+			subject = gitmojiPrefix.ReplaceAllString(subject, "")
+			subject = strings.TrimLeftFunc(subject, func(r rune) bool {
+				return isEmoji(r) || unicode.IsSpace(r)
+			})
+		}
+		if subject == prev {
+			break
+		}
+	}
+	if !hasRest {
+		return subject
+	}
+	return subject + "\n" + rest
+}
+
+// Check 规范化提交信息并判断是否保留，返回规范化后的信息。
+func (f *Filter) Check(message string) (string, Decision) {
+	normalized := f.Normalize(message)
+	subject := strings.TrimSpace(strings.Split(normalized, "\n")[0])
+	if subject == "" {
+		return normalized, Decision{Rule: RuleEmpty, Reason: "message is empty after normalization"}
+	}
+	for _, rule := range f.rules {
+		if d, decided := rule.Check(subject); decided {
+			return normalized, d
+		}
+	}
+	return normalized, Decision{Keep: true}
+}
+
+// patternRule 在首行命中任一正则时做出决定：keep 为 true 时保留，否则丢弃。
+type patternRule struct {
+	rule     string
+	keep     bool
+	patterns []*regexp.Regexp
+}
+
+func (r patternRule) Check(subject string) (Decision, bool) {
+	for _, p := range r.patterns {
+		if p.MatchString(subject) {
+			if r.keep {
+				return Decision{Keep: true, Rule: r.rule}, true
+			}
+			return Decision{Rule: r.rule, Reason: fmt.Sprintf("matches %s pattern %q", r.rule, p.String())}, true
+		}
+	}
+	return Decision{}, false
+}
+
+// languageRule 要求允许语言的字符占全部字母类字符的比例不低于 minRatio，不含字母的提交信息直接丢弃。
+type languageRule struct {
+	tables   []*unicode.RangeTable
+	minRatio float64
+}
+
+func (r languageRule) Check(subject string) (Decision, bool) {
+	letters, matched := 0, 0
+	for _, c := range subject {
+		if !unicode.IsLetter(c) {
+			continue
+		}
+		letters++
+		if unicode.In(c, r.tables...) {
+			matched++
+		}
+	}
+	if letters == 0 {
+		return Decision{Rule: RuleLanguage, Reason: "message contains no letters"}, true
+	}
+	if ratio := float64(matched) / float64(letters); ratio < r.minRatio {
+		return Decision{Rule: RuleLanguage, Reason: fmt.Sprintf("allowed language ratio %.2f below %.2f", ratio, r.minRatio)}, true
+	}
+	return Decision{}, false
+}
+
+func compileAll(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// isEmoji 报告 r 是否为 emoji 或 emoji 组合中使用的修饰字符。
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // 各类 emoji 和符号
+		r >= 0x2600 && r <= 0x27BF,            // 杂项符号和装饰符号
+		r >= 0x2B00 && r <= 0x2BFF,            // 箭头和几何图形
+		r == 0x200D, r == 0xFE0F, r == 0x20E3: // 零宽连接符、变体选择符、组合键帽
+		return true
+	}
+	return false
+}
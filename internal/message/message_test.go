@@ -0,0 +1,96 @@
+package message
+
+import "testing"
+
+func defaultFilter(t *testing.T) *Filter {
+	t.Helper()
+	f, err := New(Config{
+		Strip:     []string{StripConventional, StripTags, StripEmoji},
+		Languages: []string{"zh", "en"},
+		MinRatio:  0.5,
+		Deny:      []string{`^测试提交`},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	return f
+}
+
+func TestNormalize(t *testing.T) {
+	f := defaultFilter(t)
+	tests := []struct {
+		in, want string
+	}{
+		{"feat: 添加讲义", "添加讲义"},
+		{"fix(exam)!: 修正答案", "修正答案"},
+		{"[EE3001] 上传试卷", "上传试卷"},
+		{"【勘误】 docs：修改第三章", "修改第三章"},
+		{"✨ 新增复习资料", "新增复习资料"},
+		{":sparkles: feat: 新增复习资料", "新增复习资料"},
+		{"🎉🎉 [COMP3052] 初始化课程信息\n\n详细说明", "初始化课程信息\n\n详细说明"},
+		{"增加 25 秋考试信息 (#39)", "增加 25 秋考试信息 (#39)"},
+	}
+	for _, tt := range tests {
+		if got := f.Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	f := defaultFilter(t)
+	tests := []struct {
+		message string
+		keep    bool
+		rule    string
+	}{
+		{"feat: 添加讲义", true, ""},
+		{"Add 23AISZ Review Materials", true, ""},
+		{"[EE3001] 上传试卷", true, ""},
+		{"Update README.md", false, RuleGenerated},
+		{"Add files via upload", false, RuleGenerated},
+		{"Merge pull request #12 from user/patch-1", false, RuleGenerated},
+		{"Merge branch 'main' into dev", false, RuleGenerated},
+		{"update", false, RuleGenerated},
+		{"更新", false, RuleGenerated},
+		{"测试提交 123", false, RuleDeny},
+		{"テストを追加しました", false, RuleLanguage},
+		{"🎉", false, RuleEmpty},
+		{"1.2.3", false, RuleLanguage},
+	}
+	for _, tt := range tests {
+		_, d := f.Check(tt.message)
+		if d.Keep != tt.keep || (!tt.keep && d.Rule != tt.rule) {
+			t.Errorf("Check(%q) = %+v, want keep=%v rule=%q", tt.message, d, tt.keep, tt.rule)
+		}
+		if !d.Keep && d.Reason == "" {
+			t.Errorf("Check(%q) dropped without reason", tt.message)
+		}
+	}
+}
+
+func TestCheck_AllowOverridesOtherRules(t *testing.T) {
+	f, err := New(Config{Languages: []string{"zh"}, MinRatio: 1, Allow: []string{`^Update README\.md$`}})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	if _, d := f.Check("Update README.md"); !d.Keep || d.Rule != RuleAllow {
+		t.Errorf("expected allow pattern to keep message, got %+v", d)
+	}
+	if _, d := f.Check("Add notes"); d.Keep {
+		t.Errorf("expected English message dropped when only zh is allowed")
+	}
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []Config{
+		{Strip: []string{"unknown"}},
+		{Languages: []string{"fr"}},
+		{Deny: []string{"("}},
+		{Languages: []string{"zh"}, MinRatio: 2},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) should return error", cfg)
+		}
+	}
+}
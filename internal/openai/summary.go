@@ -23,20 +23,6 @@ type summaryResponse struct {
 // GenerateWeeklySummary 根据 rawUpdates，调用 OpenAI API 生成每周更新摘要。
 // 如果一周内仅有一个仓库更新，输出 "__NO_SUMMARY__"。
 func GenerateWeeklySummary(rawUpdates string) (string, error) {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
-		return "", fmt.Errorf("OPENAI_API_KEY is not set")
-	}
-	baseURL := os.Getenv("OPENAI_BASE_URL")
-	if baseURL == "" {
-		baseURL = "https://api.openai.com/v1"
-	}
-	baseURL = strings.TrimRight(baseURL, "/")
-	model := os.Getenv("OPENAI_MODEL")
-	if model == "" {
-		model = "gpt-5-mini"
-	}
-
 	prompt := fmt.Sprintf(`你将收到一周内学生们在各个课程仓库中的更新记录。  
 请根据这些原始更新，生成一个简洁清晰的「每周更新摘要」，要求如下：  
 
@@ -57,6 +43,26 @@ func GenerateWeeklySummary(rawUpdates string) (string, error) {
 
 请生成总结。`, rawUpdates)
 
+	return createResponse(prompt)
+}
+
+// createResponse 调用 Responses API，返回模型输出的文本。
+// 密钥、接口地址和模型分别由 OPENAI_API_KEY、OPENAI_BASE_URL、OPENAI_MODEL 环境变量指定。
+func createResponse(prompt string) (string, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return "", fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+	baseURL := os.Getenv("OPENAI_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	model := os.Getenv("OPENAI_MODEL")
+	if model == "" {
+		model = "gpt-5-mini"
+	}
+
 	reqBody, err := json.Marshal(summaryRequest{
 		Model: model,
 		Input: prompt,
@@ -0,0 +1,170 @@
+// AI 翻译：按术语表将周报摘要、课程名等中文文本译为英文，译文按原文哈希缓存
+package openai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Glossary 是中文术语 -> 英文译法的术语表，包括课程名和常用术语。
+type Glossary map[string]string
+
+// LoadGlossary 读取 YAML 格式的术语表，文件内容为中文到英文的映射。
+func LoadGlossary(path string) (Glossary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glossary %q: %w", path, err)
+	}
+	var glossary Glossary
+	if err := yaml.Unmarshal(data, &glossary); err != nil {
+		return nil, fmt.Errorf("failed to parse glossary %q: %w", path, err)
+	}
+	for term, translation := range glossary {
+		if strings.TrimSpace(term) == "" || strings.TrimSpace(translation) == "" {
+			return nil, fmt.Errorf("glossary %q has an empty entry for %q", path, term)
+		}
+	}
+	return glossary, nil
+}
+
+// relevant 返回在 text 中出现的术语，按术语排序，保证提示词和缓存键稳定。
+func (g Glossary) relevant(text string) [][2]string {
+	var terms [][2]string
+	for term, translation := range g {
+		if strings.Contains(text, term) {
+			terms = append(terms, [2]string{term, translation})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool { return terms[i][0] < terms[j][0] })
+	return terms
+}
+
+// translation 是一条缓存的译文，保留原文便于人工核对。
+type translation struct {
+	Source string `json:"source"`
+	Text   string `json:"text"`
+}
+
+// TranslationCache 缓存译文，键为原文及其涉及的术语译法的 SHA-256，
+// 原文不变且术语表中相关条目不变时直接复用，不再调用 API。
+type TranslationCache struct {
+	Entries map[string]translation `json:"entries"`
+}
+
+// LoadTranslationCache 读取缓存文件，文件不存在时返回空缓存。
+func LoadTranslationCache(path string) (*TranslationCache, error) {
+	cache := &TranslationCache{Entries: make(map[string]translation)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+	if cache.Entries == nil {
+		cache.Entries = make(map[string]translation)
+	}
+	return cache, nil
+}
+
+// Save 将缓存写入 path，键按字母序输出以保证内容稳定。
+func (c *TranslationCache) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Translator 将中文译为英文：术语表中的完整条目直接替换，其余文本调用模型翻译并写入缓存。
+type Translator struct {
+	glossary Glossary
+	cache    *TranslationCache
+	complete func(prompt string) (string, error) // 调用模型，测试中可替换
+}
+
+// NewTranslator 创建使用 glossary 和 cache 的翻译器，cache 会被原地更新，由调用方负责保存。
+func NewTranslator(glossary Glossary, cache *TranslationCache) *Translator {
+	return &Translator{glossary: glossary, cache: cache, complete: createResponse}
+}
+
+// Translate 将 text 译为英文，text 为 Markdown 时保留其格式和链接。空白文本原样返回。
+func (t *Translator) Translate(text string) (string, error) {
+	source := strings.TrimSpace(text)
+	if source == "" {
+		return text, nil
+	}
+	if translated, ok := t.glossary[source]; ok {
+		return translated, nil
+	}
+	terms := t.glossary.relevant(source)
+	key := translationKey(source, terms)
+	if cached, ok := t.cache.Entries[key]; ok {
+		return cached.Text, nil
+	}
+
+	translated, err := t.complete(translationPrompt(source, terms))
+	if err != nil {
+		return "", fmt.Errorf("failed to translate %q: %w", truncate(source, 40), err)
+	}
+	translated = strings.TrimSpace(translated)
+	if translated == "" {
+		return "", fmt.Errorf("failed to translate %q: empty response", truncate(source, 40))
+	}
+	t.cache.Entries[key] = translation{Source: source, Text: translated}
+	return translated, nil
+}
+
+// translationKey 返回缓存键，术语表中与原文相关的条目变化后会重新翻译。
+func translationKey(source string, terms [][2]string) string {
+	h := sha256.New()
+	h.Write([]byte(source))
+	for _, term := range terms {
+		fmt.Fprintf(h, "\x00%s\x00%s", term[0], term[1])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func translationPrompt(source string, terms [][2]string) string {
+	var b strings.Builder
+	b.WriteString(`请将下面的中文内容翻译为英文，要求如下：
+
+1. 只输出译文，不要添加解释、引号或额外的标题。
+2. 保留原有的 Markdown 结构：标题层级、列表、加粗、链接地址和代码保持不变，只翻译可见文字。
+3. 课程名、人名和仓库名如果没有给出译法，课程名意译，人名和仓库名保留原文。
+`)
+	if len(terms) > 0 {
+		b.WriteString("4. 以下术语必须使用给定的译法：\n\n")
+		for _, term := range terms {
+			fmt.Fprintf(&b, "- %s => %s\n", term[0], term[1])
+		}
+	}
+	b.WriteString("\n下面是需要翻译的内容：\n\n")
+	b.WriteString(source)
+	return b.String()
+}
+
+// truncate 截断过长的文本，用于错误信息。
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,107 @@
+package openai
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTranslator_GlossaryAndCache(t *testing.T) {
+	glossary := Glossary{"电路原理": "Principles of Electric Circuits", "期末试卷": "final exam papers"}
+	cache := &TranslationCache{Entries: make(map[string]translation)}
+	tr := NewTranslator(glossary, cache)
+	var prompts []string
+	tr.complete = func(prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		return " Uploaded final exam papers for Principles of Electric Circuits.\n", nil
+	}
+
+	if got, err := tr.Translate("电路原理"); err != nil || got != "Principles of Electric Circuits" {
+		t.Errorf("Translate(course) = %q, %v", got, err)
+	}
+	if len(prompts) != 0 {
+		t.Fatalf("exact glossary entries should not call the model")
+	}
+
+	source := "电路原理新增了期末试卷。"
+	for i := 0; i < 2; i++ {
+		got, err := tr.Translate(source)
+		if err != nil {
+			t.Fatalf("Translate() returned error: %v", err)
+		}
+		if got != "Uploaded final exam papers for Principles of Electric Circuits." {
+			t.Errorf("Translate() = %q", got)
+		}
+	}
+	if len(prompts) != 1 {
+		t.Fatalf("expected one model call thanks to the cache, got %d", len(prompts))
+	}
+	if !strings.Contains(prompts[0], "- 期末试卷 => final exam papers\n") || !strings.Contains(prompts[0], source) {
+		t.Errorf("prompt should list relevant glossary terms:\n%s", prompts[0])
+	}
+
+	// 相关术语的译法变化后重新翻译
+	glossary["期末试卷"] = "final exams"
+	if _, err := tr.Translate(source); err != nil {
+		t.Fatalf("Translate() returned error: %v", err)
+	}
+	if len(prompts) != 2 {
+		t.Errorf("changing a relevant glossary entry should invalidate the cache")
+	}
+}
+
+func TestTranslator_Errors(t *testing.T) {
+	tr := NewTranslator(nil, &TranslationCache{Entries: make(map[string]translation)})
+	tr.complete = func(string) (string, error) { return "", errors.New("rate limited") }
+	if _, err := tr.Translate("新增资料"); err == nil {
+		t.Errorf("expected error from the model")
+	}
+	tr.complete = func(string) (string, error) { return "  ", nil }
+	if _, err := tr.Translate("新增资料"); err == nil {
+		t.Errorf("expected error for empty translation")
+	}
+	if len(tr.cache.Entries) != 0 {
+		t.Errorf("failed translations should not be cached")
+	}
+	if got, err := tr.Translate(" \n"); err != nil || got != " \n" {
+		t.Errorf("blank text should be returned as is, got %q, %v", got, err)
+	}
+}
+
+func TestTranslationCache_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "translations.json")
+	cache, err := LoadTranslationCache(path)
+	if err != nil || len(cache.Entries) != 0 {
+		t.Fatalf("LoadTranslationCache() on missing file = %+v, %v", cache, err)
+	}
+	cache.Entries["k"] = translation{Source: "新增资料", Text: "New materials"}
+	if err := cache.Save(path); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	loaded, err := LoadTranslationCache(path)
+	if err != nil {
+		t.Fatalf("LoadTranslationCache() returned error: %v", err)
+	}
+	if loaded.Entries["k"].Text != "New materials" {
+		t.Errorf("round trip mismatch: %+v", loaded.Entries)
+	}
+}
+
+func TestLoadGlossary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glossary.yaml")
+	if err := os.WriteFile(path, []byte("电路原理: Principles of Electric Circuits\n"), 0o644); err != nil {
+		t.Fatalf("failed to write glossary: %v", err)
+	}
+	glossary, err := LoadGlossary(path)
+	if err != nil || glossary["电路原理"] != "Principles of Electric Circuits" {
+		t.Errorf("LoadGlossary() = %v, %v", glossary, err)
+	}
+	if err := os.WriteFile(path, []byte("电路原理: \"\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write glossary: %v", err)
+	}
+	if _, err := LoadGlossary(path); err == nil {
+		t.Errorf("expected error for empty translation")
+	}
+}
@@ -0,0 +1,144 @@
+// PR 归属：将 squash 合并和 merge commit 关联到对应的 pull request，并识别共同作者
+package report
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/bots"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+var (
+	squashPRRef  = regexp.MustCompile(`\(#(\d+)\)\s*$`)                                // squash 合并时 GitHub 在首行末尾追加的 PR 编号
+	mergePRRef   = regexp.MustCompile(`^Merge pull request #(\d+) from `)              // merge commit 的默认首行
+	coAuthorLine = regexp.MustCompile(`(?mi)^co-authored-by:\s*(.*?)\s*<([^>]*)>\s*$`) // Co-authored-by 尾注
+	noreplyEmail = regexp.MustCompile(`^(?:\d+\+)?([A-Za-z0-9-]+)@users\.noreply\.github\.com$`)
+)
+
+// pullRequestLookup 查询指定仓库中编号为 number 的 pull request。
+type pullRequestLookup func(orgName, repo string, number int) (github.PullRequest, error)
+
+// cachedPullRequests 返回带进程内缓存的 pullRequestLookup，同一个 PR 只请求一次 API。查询失败不缓存。
+func cachedPullRequests(lookup pullRequestLookup) pullRequestLookup {
+	var (
+		mu    sync.Mutex
+		cache = make(map[string]github.PullRequest)
+	)
+	return func(orgName, repo string, number int) (github.PullRequest, error) {
+		key := fmt.Sprintf("%s/%s#%d", orgName, repo, number)
+		mu.Lock()
+		pr, ok := cache[key]
+		mu.Unlock()
+		if ok {
+			return pr, nil
+		}
+		pr, err := lookup(orgName, repo, number)
+		if err != nil {
+			return pr, err
+		}
+		mu.Lock()
+		cache[key] = pr
+		mu.Unlock()
+		return pr, nil
+	}
+}
+
+// pullRequestRef 从提交信息首行中解析 PR 编号，isMerge 表示这是一条 merge commit。
+func pullRequestRef(message string) (number int, isMerge bool) {
+	subject := strings.Split(message, "\n")[0]
+	if m := mergePRRef.FindStringSubmatch(subject); m != nil {
+		number, _ = strconv.Atoi(m[1])
+		return number, true
+	}
+	if m := squashPRRef.FindStringSubmatch(subject); m != nil {
+		number, _ = strconv.Atoi(m[1])
+	}
+	return number, false
+}
+
+// resolvePullRequest 查询提交关联的 PR，未关联、未开启查询或查询失败时返回 nil。
+func resolvePullRequest(orgName, repo string, commit github.Commit, opts collectOptions) *github.PullRequest {
+	number, _ := pullRequestRef(commit.Commit.Message)
+	if number == 0 || opts.pullRequests == nil {
+		return nil
+	}
+	pr, err := opts.pullRequests(orgName, repo, number)
+	if err != nil {
+		log.Printf("Failed to fetch pull request %s#%d: %v", repo, number, err)
+		return nil
+	}
+	return &pr
+}
+
+// parseCoAuthors 解析提交信息中的 Co-authored-by 尾注，归并身份后返回，跳过 bot 和与 author 相同的身份。
+func parseCoAuthors(message string, author identity.Identity, resolver *identity.Resolver, detector *bots.Detector) []identity.Identity {
+	seen := map[string]struct{}{identityKey(author): {}}
+	coAuthors := make([]identity.Identity, 0)
+	for _, m := range coAuthorLine.FindAllStringSubmatch(message, -1) {
+		name, email := m[1], m[2]
+		login := ""
+		if lm := noreplyEmail.FindStringSubmatch(email); lm != nil {
+			login = lm[1]
+		}
+		if isBot, _ := detector.Check(name, login, ""); isBot {
+			continue
+		}
+		id := resolver.Resolve(name, email, login)
+		if id.Name == "" {
+			id.Name = id.Login
+		}
+		if id.Name == "" {
+			continue
+		}
+		key := identityKey(id)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		coAuthors = append(coAuthors, id)
+	}
+	return coAuthors
+}
+
+// identityKey 返回用于比较身份的键，与 contributorKey 的规则一致。
+func identityKey(id identity.Identity) string {
+	return contributorKey(CommitEntry{AuthorName: id.Name, AuthorLogin: id.Login})
+}
+
+// commitCredits 返回一条提交应当计入的全部身份：作者及共同作者。
+func commitCredits(commit CommitEntry) []identity.Identity {
+	credits := []identity.Identity{{Name: commit.AuthorName, Login: commit.AuthorLogin, Avatar: commit.AuthorAvatar}}
+	return append(credits, commit.CoAuthors...)
+}
+
+// renderMessage 渲染提交信息首行，关联了 PR 时将末尾的 PR 编号替换为链接，并附上 commit 链接。
+func renderMessage(commit CommitEntry) string {
+	subject := strings.Split(commit.Message, "\n")[0]
+	if commit.PRNumber > 0 {
+		subject = strings.TrimSpace(squashPRRef.ReplaceAllString(subject, ""))
+	}
+	message := utils.SanitizeInlineText(subject)
+
+	refs := make([]string, 0, 2)
+	if commit.PRNumber > 0 && commit.PRURL != "" {
+		refs = append(refs, utils.RenderSafeMarkdownLink(fmt.Sprintf("#%d", commit.PRNumber), commit.PRURL))
+	}
+	if commit.SHA != "" && commit.URL != "" {
+		short := commit.SHA
+		if len(short) > 7 {
+			short = short[:7]
+		}
+		refs = append(refs, utils.RenderSafeMarkdownLink(short, commit.URL))
+	}
+	if len(refs) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(refs, ", "))
+}
@@ -0,0 +1,133 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/message"
+)
+
+func TestPullRequestRef(t *testing.T) {
+	tests := []struct {
+		message string
+		number  int
+		isMerge bool
+	}{
+		{"新增光计算概论的课程信息及教师评价 (#2)", 2, false},
+		{"Merge pull request #15 from alice/main\n\n上传期末试卷", 15, true},
+		{"修正 #3 中的错误", 0, false},
+		{"上传试卷\n\n(#4)", 0, false},
+	}
+	for _, tt := range tests {
+		number, isMerge := pullRequestRef(tt.message)
+		if number != tt.number || isMerge != tt.isMerge {
+			t.Errorf("pullRequestRef(%q) = (%d, %v), want (%d, %v)", tt.message, number, isMerge, tt.number, tt.isMerge)
+		}
+	}
+}
+
+func TestToCommitEntries_CreditsPullRequestAuthor(t *testing.T) {
+	commits := mustCommits(t, `[
+		{"sha": "0123456789abcdef", "html_url": "https://github.com/HITSZ-OpenAuto/EE3001/commit/0123456789abcdef",
+		 "commit": {"author": {"name": "Maintainer", "email": "m@example.com", "date": "2026-02-13T02:00:00Z"},
+		            "message": "新增光计算概论的课程信息及教师评价 (#2)\n\nCo-authored-by: Bob <123+bob@users.noreply.github.com>\nCo-authored-by: Maintainer <m@example.com>"},
+		 "author": {"login": "maintainer"}},
+		{"sha": "fedcba9876543210",
+		 "commit": {"author": {"name": "Maintainer", "email": "m@example.com", "date": "2026-02-13T03:00:00Z"},
+		            "message": "Merge pull request #5 from carol/main\n\nupload"},
+		 "author": {"login": "maintainer"}}
+	]`)
+	prs := map[int]github.PullRequest{
+		2: {Number: 2, Title: "新增光计算概论", HTMLURL: "https://github.com/HITSZ-OpenAuto/EE3001/pull/2", User: github.Author{Login: "alice"}},
+		5: {Number: 5, Title: "上传期末试卷", HTMLURL: "https://github.com/HITSZ-OpenAuto/EE3001/pull/5", User: github.Author{Login: "carol"}},
+	}
+	opts := newCollectOptions(&config.Config{Aliases: []identity.Alias{{Name: "爱丽丝", Login: "alice"}}}, false)
+	opts.pullRequests = func(orgName, repo string, number int) (github.PullRequest, error) {
+		if pr, ok := prs[number]; ok {
+			return pr, nil
+		}
+		return github.PullRequest{}, errors.New("not found")
+	}
+
+	entries := toCommitEntries("HITSZ-OpenAuto", "EE3001", commits, opts)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	squash := entries[0]
+	if squash.AuthorLogin != "alice" || squash.AuthorName != "爱丽丝" || squash.PRNumber != 2 {
+		t.Errorf("squash commit credited to %q/%q (PR #%d), want 爱丽丝/alice (PR #2)", squash.AuthorName, squash.AuthorLogin, squash.PRNumber)
+	}
+	if len(squash.CoAuthors) != 2 || squash.CoAuthors[0].Login != "bob" || squash.CoAuthors[1].Name != "Maintainer" {
+		t.Errorf("CoAuthors = %+v, want bob and Maintainer", squash.CoAuthors)
+	}
+
+	merge := entries[1]
+	if merge.AuthorLogin != "carol" || merge.Message != "上传期末试卷" {
+		t.Errorf("merge commit = %q by %q, want PR title by carol", merge.Message, merge.AuthorLogin)
+	}
+	if merge.URL != "https://github.com/HITSZ-OpenAuto/EE3001/commit/fedcba9876543210" {
+		t.Errorf("merge commit URL = %q, want fallback commit URL", merge.URL)
+	}
+}
+
+func TestToCommitEntries_SkipsPullRequestLookupForDroppedCommits(t *testing.T) {
+	commits := mustCommits(t, `[
+		{"sha": "0123456789abcdef",
+		 "commit": {"author": {"name": "Alice", "email": "a@example.com", "date": "2026-02-13T02:00:00Z"}, "message": "テストを追加しました (#2)"}},
+		{"sha": "fedcba9876543210",
+		 "commit": {"author": {"name": "Alice", "email": "a@example.com", "date": "2026-02-13T03:00:00Z"}, "message": "Merge pull request #5 from carol/main"}}
+	]`)
+	opts := newCollectOptions(&config.Config{Messages: config.MessagesConfig{Config: message.Config{Languages: []string{"zh", "en"}, MinRatio: 0.5}}}, false)
+	var looked []int
+	opts.pullRequests = func(orgName, repo string, number int) (github.PullRequest, error) {
+		looked = append(looked, number)
+		return github.PullRequest{Number: number, Title: "上传期末试卷", User: github.Author{Login: "carol"}}, nil
+	}
+
+	entries := toCommitEntries("HITSZ-OpenAuto", "EE3001", commits, opts)
+	if len(entries) != 1 || entries[0].Message != "上传期末试卷" {
+		t.Fatalf("expected only the merge commit to be kept with the PR title, got %+v", entries)
+	}
+	if len(looked) != 1 || looked[0] != 5 {
+		t.Errorf("PR lookups = %v, want only #5 for the merge commit", looked)
+	}
+}
+
+func TestRenderMessage_LinksPullRequestAndCommit(t *testing.T) {
+	commit := CommitEntry{
+		Message:  "新增光计算概论的课程信息及教师评价 (#2)",
+		SHA:      "0123456789abcdef",
+		URL:      "https://github.com/HITSZ-OpenAuto/EE3001/commit/0123456789abcdef",
+		PRNumber: 2,
+		PRURL:    "https://github.com/HITSZ-OpenAuto/EE3001/pull/2",
+	}
+	want := "新增光计算概论的课程信息及教师评价 ([#2](https://github.com/HITSZ-OpenAuto/EE3001/pull/2), [0123456](https://github.com/HITSZ-OpenAuto/EE3001/commit/0123456789abcdef))"
+	if got := renderMessage(commit); got != want {
+		t.Errorf("renderMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestGroupContributors_CreditsCoAuthors(t *testing.T) {
+	commits := []CommitEntry{{
+		AuthorName:  "Alice",
+		AuthorLogin: "alice",
+		CoAuthors:   []identity.Identity{{Name: "Bob", Login: "bob"}},
+		Date:        time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC),
+		Message:     "合作整理笔记",
+		RepoName:    "EE3001",
+	}}
+	got := groupContributors(commits, nil)
+	if len(got) != 2 || got[0].Login != "alice" || got[1].Login != "bob" || got[1].Name != "Bob" {
+		t.Fatalf("expected alice and bob credited, got %+v", got)
+	}
+	if author := renderAuthor(i18n.Default, commits[0]); !strings.Contains(author, "[Bob](https://github.com/bob)") {
+		t.Errorf("renderAuthor() should include co-authors, got %q", author)
+	}
+}
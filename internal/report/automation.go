@@ -0,0 +1,79 @@
+// 自动化操作识别：相同提交信息在极短时间内出现在大量仓库中，通常来自同步脚本
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/bots"
+)
+
+// 被过滤提交记录中 bot 与自动化操作使用的规则名。
+const (
+	ruleBot        = "bot"
+	ruleAutomation = "automation"
+)
+
+// detectAutomation 找出被识别为自动化操作的提交，返回其在 commits 中的下标。
+// 按规范化后的提交信息分组（不区分作者），组内相邻提交间隔不超过 cfg.Window 的提交聚为一簇，
+// 涉及的仓库数不少于 cfg.MinRepos 的簇整体视为自动化操作。cfg.MinRepos 为 0 时不做识别。
+func detectAutomation(commits []CommitEntry, cfg bots.AutomationConfig) map[int]struct{} {
+	automated := make(map[int]struct{})
+	if cfg.MinRepos <= 0 {
+		return automated
+	}
+
+	byMessage := make(map[string][]int)
+	for i, commit := range commits {
+		key := normalizeMessage(commit.Message)
+		byMessage[key] = append(byMessage[key], i)
+	}
+	for _, indices := range byMessage {
+		if len(indices) < cfg.MinRepos {
+			continue
+		}
+		sort.SliceStable(indices, func(a, b int) bool {
+			return commits[indices[a]].Date.Before(commits[indices[b]].Date)
+		})
+		for start := 0; start < len(indices); {
+			end := start + 1
+			for end < len(indices) && commits[indices[end]].Date.Sub(commits[indices[end-1]].Date) <= cfg.Window {
+				end++
+			}
+			repos := make(map[string]struct{})
+			for _, i := range indices[start:end] {
+				repos[commits[i].RepoName] = struct{}{}
+			}
+			if len(repos) >= cfg.MinRepos {
+				for _, i := range indices[start:end] {
+					automated[i] = struct{}{}
+				}
+			}
+			start = end
+		}
+	}
+	return automated
+}
+
+// removeAutomation 从 commits 中移除自动化操作并记录到 drops，返回剩余的提交。
+func removeAutomation(commits []CommitEntry, cfg bots.AutomationConfig, drops *dropRecorder) []CommitEntry {
+	automated := detectAutomation(commits, cfg)
+	if len(automated) == 0 {
+		return commits
+	}
+	kept := make([]CommitEntry, 0, len(commits)-len(automated))
+	for i, commit := range commits {
+		if _, ok := automated[i]; !ok {
+			kept = append(kept, commit)
+			continue
+		}
+		drops.record(droppedCommit{
+			Repo:    commit.RepoName,
+			SHA:     commit.SHA,
+			Message: commit.Message,
+			Rule:    ruleAutomation,
+			Reason:  fmt.Sprintf("same message pushed to at least %d repos within %s of each other", cfg.MinRepos, cfg.Window),
+		})
+	}
+	return kept
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/bots"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+)
+
+func TestRemoveAutomation(t *testing.T) {
+	base := time.Date(2026, 2, 13, 10, 0, 0, 0, time.UTC)
+	var commits []CommitEntry
+	for i := 0; i < 5; i++ {
+		// 同步脚本以不同的人名提交，间隔数秒
+		commits = append(commits, CommitEntry{
+			AuthorName: fmt.Sprintf("维护者%d", i%2),
+			Date:       base.Add(time.Duration(i) * 3 * time.Second),
+			Message:    "同步课程模板",
+			RepoName:   fmt.Sprintf("REPO%d", i),
+		})
+	}
+	commits = append(commits,
+		CommitEntry{AuthorName: "张三", Date: base, Message: "上传试卷", RepoName: "REPO0"},
+		CommitEntry{AuthorName: "李四", Date: base.Add(time.Hour), Message: "同步课程模板", RepoName: "REPO9"}, // 超出时间窗口
+	)
+
+	drops := newDropRecorder("")
+	kept := removeAutomation(commits, bots.AutomationConfig{MinRepos: 5, Window: 10 * time.Second}, drops)
+	if len(kept) != 2 {
+		t.Fatalf("expected 2 commits kept, got %d: %+v", len(kept), kept)
+	}
+	if n := drops.count(ruleAutomation); n != 5 {
+		t.Errorf("expected 5 automation records, got %d", n)
+	}
+
+	if got := removeAutomation(commits, bots.AutomationConfig{}, drops); len(got) != len(commits) {
+		t.Errorf("detection should be disabled when min_repos is 0")
+	}
+}
+
+func TestToCommitEntries_RecordsBots(t *testing.T) {
+	commits := mustCommits(t, `[
+		{"sha": "b1", "commit": {"author": {"name": "某应用", "email": "", "date": "2026-02-13T02:00:00Z"}, "message": "自动更新"}, "author": {"login": "some-app", "type": "Bot"}},
+		{"sha": "b2", "commit": {"author": {"name": "张三", "email": "", "date": "2026-02-13T02:00:00Z"}, "message": "上传试卷"}, "author": {"login": "zhangsan", "type": "User"}}
+	]`)
+	opts := newCollectOptions(&config.Config{}, false)
+	entries := toCommitEntries("HITSZ-OpenAuto", "EE3001", commits, opts)
+	if len(entries) != 1 || entries[0].AuthorLogin != "zhangsan" {
+		t.Fatalf("expected only zhangsan kept, got %+v", entries)
+	}
+	if n := opts.drops.count(ruleBot); n != 1 {
+		t.Errorf("expected bot commit recorded, got %d", n)
+	}
+}
@@ -0,0 +1,77 @@
+// 按类别分组渲染 commit
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// defaultCategorizer 用于为收集阶段未分类的 commit（例如测试数据）补充类别。
+var defaultCategorizer = categorize.New(nil)
+
+// categoryGroup 是同一类别下的一组 commit。
+type categoryGroup struct {
+	Category categorize.Category
+	Commits  []CommitEntry
+}
+
+// categoryOf 返回 commit 的类别，收集阶段未分类时按内置规则分类。
+func categoryOf(commit CommitEntry) categorize.Category {
+	if commit.Category != "" {
+		return commit.Category
+	}
+	return defaultCategorizer.Categorize(commit.Message, commit.Files)
+}
+
+// groupByCategory 按 categorize.Order 的顺序将 commit 分组，组内保持原有顺序，省略空组。
+func groupByCategory(commits []CommitEntry) []categoryGroup {
+	byCategory := make(map[categorize.Category][]CommitEntry)
+	for _, commit := range commits {
+		category := categoryOf(commit)
+		if !category.Valid() {
+			category = categorize.Other
+		}
+		byCategory[category] = append(byCategory[category], commit)
+	}
+	groups := make([]categoryGroup, 0, len(byCategory))
+	for _, category := range categorize.Order {
+		if len(byCategory[category]) > 0 {
+			groups = append(groups, categoryGroup{Category: category, Commits: byCategory[category]})
+		}
+	}
+	return groups
+}
+
+// categoryTitle 返回类别在 lang 语言下的标题，未知类别按「其他更新」处理。
+func categoryTitle(lang i18n.Lang, category categorize.Category) string {
+	if !category.Valid() {
+		category = categorize.Other
+	}
+	return lang.T("category." + string(category))
+}
+
+// trivialCourseLinks 返回日常维护类 commit（含去重合并的同批次提交）涉及的课程链接，按仓库名排序。
+func trivialCourseLinks(commits []CommitEntry, repoTitles map[string]string, orgName string) []string {
+	seen := make(map[string]struct{})
+	repos := make([]string, 0)
+	for _, commit := range commits {
+		for _, repo := range batchRepos(commit, repoTitles) {
+			if _, ok := seen[repo]; ok {
+				continue
+			}
+			seen[repo] = struct{}{}
+			repos = append(repos, repo)
+		}
+	}
+	sort.Strings(repos)
+
+	links := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		links = append(links, utils.RenderSafeMarkdownLink(courseTitle(repo, repoTitles), fmt.Sprintf("https://github.com/%s/%s", orgName, repo)))
+	}
+	return links
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+)
+
+func TestGroupByCategory(t *testing.T) {
+	commits := []CommitEntry{
+		{Message: "删除旧 toml", RepoName: "r1"},
+		{Message: "上传试卷", RepoName: "r1"},
+		{Message: "随便写写", RepoName: "r2", Category: categorize.Material}, // 收集阶段的分类优先
+		{Message: "修复错误", RepoName: "r2"},
+	}
+	groups := groupByCategory(commits)
+
+	want := []categorize.Category{categorize.Material, categorize.Correction, categorize.Trivial}
+	if len(groups) != len(want) {
+		t.Fatalf("expected %d groups, got %+v", len(want), groups)
+	}
+	for i, g := range groups {
+		if g.Category != want[i] {
+			t.Errorf("group %d = %q, want %q", i, g.Category, want[i])
+		}
+	}
+	if len(groups[0].Commits) != 2 || groups[0].Commits[0].Message != "上传试卷" {
+		t.Errorf("expected material group to keep original order, got %+v", groups[0].Commits)
+	}
+}
+
+func TestBuildMarkdown_GroupsByCategoryAndCollapsesTrivial(t *testing.T) {
+	day := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	commits := []CommitEntry{
+		{AuthorName: "甲", Date: day, Message: "删除旧 toml", RepoName: "EE3001"},
+		{AuthorName: "乙", Date: day.Add(time.Minute), Message: "删除旧 toml", RepoName: "COMP3052"},
+		{AuthorName: "丙", Date: day.Add(2 * time.Minute), Message: "上传试卷", RepoName: "EE3001"},
+		{AuthorName: "丁", Date: day.Add(3 * time.Minute), Message: "修复错误", RepoName: "EE3001"},
+	}
+	result := BuildMarkdown(commits, map[string]string{"EE3001": "电路原理"}, "HITSZ-OpenAuto")
+
+	iMaterial := strings.Index(result, "#### 新增资料")
+	iCorrection := strings.Index(result, "#### 勘误修正")
+	iTrivial := strings.Index(result, "#### 日常维护")
+	if !(iMaterial >= 0 && iMaterial < iCorrection && iCorrection < iTrivial) {
+		t.Fatalf("expected category headings in order, got:\n%s", result)
+	}
+	want := "- 共 2 条日常维护提交，涉及 [COMP3052](https://github.com/HITSZ-OpenAuto/COMP3052)、[电路原理](https://github.com/HITSZ-OpenAuto/EE3001)"
+	if !strings.Contains(result, want) {
+		t.Errorf("expected collapsed trivial line %q, got:\n%s", want, result)
+	}
+	if strings.Contains(result, "甲 在") || strings.Contains(result, "乙 在") {
+		t.Errorf("trivial commits should not be listed individually, got:\n%s", result)
+	}
+}
+
+func TestTrivialCourseLinks_EscapesTitles(t *testing.T) {
+	links := trivialCourseLinks([]CommitEntry{{RepoName: "EE3001"}}, map[string]string{"EE3001": "Circuits [Part 1]"}, "HITSZ-OpenAuto")
+	want := `[Circuits \[Part 1\]](https://github.com/HITSZ-OpenAuto/EE3001)`
+	if len(links) != 1 || links[0] != want {
+		t.Errorf("trivialCourseLinks() = %q, want %q", links, want)
+	}
+}
+
+func TestBuildDailyBody_GroupsByCategory(t *testing.T) {
+	date := time.Date(2026, 2, 13, 10, 0, 0, 0, time.UTC)
+	commits := []CommitEntry{
+		{AuthorName: "甲", Date: date, Message: "格式调整", RepoName: "repo-a"},
+		{AuthorName: "乙", Date: date, Message: "新增课件", RepoName: "repo-b"},
+	}
+	body := renderDailyBody(t, "test-org", commits, map[string]string{}, nil, nil, nil, 0)
+
+	if !strings.Contains(body, "## 最近更新\n\n### 新增资料\n\n- 乙 在 [repo-b](https://github.com/test-org/repo-b) 中提交了信息：新增课件 (10:00)") {
+		t.Errorf("expected material section first, got:\n%s", body)
+	}
+	if !strings.Contains(body, "### 日常维护\n\n- 共 1 条日常维护提交，涉及 [repo-a](https://github.com/test-org/repo-a)") {
+		t.Errorf("expected collapsed trivial section, got:\n%s", body)
+	}
+}
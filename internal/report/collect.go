@@ -0,0 +1,300 @@
+// 日报、周报、课程页和贡献者页共用的 commit 收集逻辑
+package report
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/bots"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/message"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// collectOptions 保存收集阶段对每条 commit 的处理配置。
+type collectOptions struct {
+	resolver     *identity.Resolver      // 作者身份归并
+	categorizer  *categorize.Categorizer // commit 分类
+	fetchFiles   bool                    // 是否拉取每条提交的变更文件列表
+	pullRequests pullRequestLookup       // 查询提交关联的 PR，为 nil 时不做 PR 归属
+	filter       *message.Filter         // 提交信息的规范化和过滤
+	drops        *dropRecorder           // 记录被过滤的提交
+	bots         *bots.Detector          // bot 账号识别
+	automation   bots.AutomationConfig   // 自动化操作识别
+	courseMeta   *courseMetaCache        // 课程元数据缓存
+}
+
+// newCollectOptions 根据配置构建收集选项。allowFetchFiles 为 false 时忽略 categories.fetch_files，
+// 用于拉取完整历史的场景，避免为每条历史提交额外请求一次 API。
+func newCollectOptions(cfg *config.Config, allowFetchFiles bool) collectOptions {
+	filter, err := message.New(cfg.Messages.Config)
+	if err != nil {
+		// 配置在加载时已校验，这里只会在直接构造 Config 时出现
+		log.Printf("Invalid message filter config, using defaults: %v", err)
+		filter, _ = message.New(message.Config{})
+	}
+	detector, err := bots.New(cfg.Bots)
+	if err != nil {
+		log.Printf("Invalid bots config, using built-in list only: %v", err)
+		detector = nil
+	}
+	opts := collectOptions{
+		resolver:    identity.NewResolver(cfg.Aliases),
+		categorizer: categorize.New(cfg.Categories.Rules),
+		fetchFiles:  allowFetchFiles && cfg.Categories.FetchFiles,
+		filter:      filter,
+		drops:       newDropRecorder(cfg.Messages.DropLog),
+		bots:        detector,
+		automation:  cfg.Bots.Automation,
+		courseMeta:  loadCourseMetaCache(cfg.CacheDir, github.GetReadmeToml),
+	}
+	if cfg.Attribution.PullRequests {
+		opts.pullRequests = cachedPullRequests(github.GetPullRequest)
+	}
+	return opts
+}
+
+// collectCommits 遍历所有公开仓库，拉取 since 之后的 commit，
+// 过滤 bot 提交和不符合提交信息规则的提交、归并作者身份，并为存在有效提交的仓库获取课程元数据。
+// 返回无序的 commit 列表和 repo 名 -> 课程元数据的映射。
+func collectCommits(orgName string, publicRepos map[string]struct{}, since time.Time, limit int, opts collectOptions) ([]CommitEntry, map[string]CourseMeta) {
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+		// 所有协程可见、共享的信息，需要保护
+		commits = make([]CommitEntry, 0)      // 过滤 bot 后的 commit 列表，无序
+		courses = make(map[string]CourseMeta) // repo 名 -> 课程元数据的映射
+		goLimit = make(chan struct{}, limit)
+	)
+
+	for repo := range publicRepos {
+		wg.Add(1)
+		go func(repo string) {
+			defer wg.Done()
+
+			goLimit <- struct{}{}        // 获取限流令牌
+			defer func() { <-goLimit }() // 释放令牌
+
+			repoCommits, err := github.ListCommitsSince(orgName, repo, since.Format(time.RFC3339))
+			if err != nil {
+				log.Printf("Failed to fetch commits for %s: %v", repo, err)
+				return
+			}
+
+			localCommits := toCommitEntries(orgName, repo, repoCommits, opts) // 区分本地和全局，是为了减少锁的粒度，提升性能
+			if len(localCommits) == 0 {
+				log.Printf("Finished commits process for %s (no valid commits)", repo)
+				return
+			} // 仅当存在有效提交时，尝试获取课程元数据，减少不必要的 API 调用
+
+			// 能从变更文件列表确认 readme.toml 未被修改时，直接使用缓存
+			untouched := readmeUntouched(len(repoCommits), localCommits)
+			meta, ok := loadCourseMeta(orgName, repo, since, untouched, opts.courseMeta)
+
+			if ok {
+				for i := range localCommits {
+					localCommits[i].Course = meta
+				}
+			}
+
+			mu.Lock()
+			commits = append(commits, localCommits...)
+			if ok {
+				courses[repo] = meta
+			}
+			mu.Unlock()
+
+			log.Printf("Finished commits process for %s", repo)
+		}(repo)
+	}
+	wg.Wait()
+	commits = removeAutomation(commits, opts.automation, opts.drops)
+	opts.drops.flush()
+	if err := opts.courseMeta.save(); err != nil {
+		log.Printf("Failed to save course meta cache: %v", err)
+	}
+
+	log.Printf("Commit collection complete, %d total valid commits", len(commits))
+	return commits, courses
+}
+
+// loadCourseMeta 通过缓存获取仓库的课程元数据。校验失败但解析出课程名时仍然使用，其余错误只记录日志。
+func loadCourseMeta(orgName, repo string, since time.Time, untouched bool, cache *courseMetaCache) (CourseMeta, bool) {
+	meta, err := cache.lookup(orgName, repo, since, untouched)
+	if err != nil {
+		log.Printf("Failed to load readme.toml for %s: %v", repo, err)
+		return meta, meta.Name != ""
+	}
+	return meta, true
+}
+
+// repoHistory 保存单个仓库的完整提交历史和课程元数据。
+type repoHistory struct {
+	Repo    string
+	Meta    CourseMeta // 获取或解析失败时为零值
+	Commits []CommitEntry
+}
+
+// collectHistory 并发拉取所有公开仓库的完整提交历史和课程元数据，
+// 供课程页和贡献者页使用。拉取失败的仓库会被跳过，返回结果按仓库名排序。
+func collectHistory(orgName string, publicRepos map[string]struct{}, limit int, opts collectOptions) []repoHistory {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		histories = make([]repoHistory, 0, len(publicRepos))
+		goLimit   = make(chan struct{}, limit)
+	)
+
+	for repo := range publicRepos {
+		wg.Add(1)
+		go func(repo string) {
+			defer wg.Done()
+
+			goLimit <- struct{}{}        // 获取限流令牌
+			defer func() { <-goLimit }() // 释放令牌
+
+			repoCommits, err := github.ListCommits(orgName, repo)
+			if err != nil {
+				log.Printf("Failed to fetch commits for %s: %v", repo, err)
+				return
+			}
+			meta, _ := loadCourseMeta(orgName, repo, time.Time{}, false, opts.courseMeta)
+			entries := toCommitEntries(orgName, repo, repoCommits, opts)
+			for i := range entries {
+				entries[i].Course = meta
+			}
+
+			mu.Lock()
+			histories = append(histories, repoHistory{
+				Repo:    repo,
+				Meta:    meta,
+				Commits: entries,
+			})
+			mu.Unlock()
+		}(repo)
+	}
+	wg.Wait()
+	removeHistoryAutomation(histories, opts)
+	opts.drops.flush()
+	if err := opts.courseMeta.save(); err != nil {
+		log.Printf("Failed to save course meta cache: %v", err)
+	}
+
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].Repo < histories[j].Repo
+	})
+	log.Printf("History collection complete, %d repos", len(histories))
+	return histories
+}
+
+// removeHistoryAutomation 跨仓库识别自动化操作，并从各仓库的历史中移除。
+func removeHistoryAutomation(histories []repoHistory, opts collectOptions) {
+	var all []CommitEntry
+	for _, history := range histories {
+		all = append(all, history.Commits...)
+	}
+	if len(detectAutomation(all, opts.automation)) == 0 {
+		return
+	}
+	kept := removeAutomation(all, opts.automation, opts.drops)
+	byRepo := make(map[string][]CommitEntry)
+	for _, commit := range kept {
+		byRepo[commit.RepoName] = append(byRepo[commit.RepoName], commit)
+	}
+	for i := range histories {
+		histories[i].Commits = byRepo[histories[i].Repo]
+	}
+}
+
+// toCommitEntries 将 GitHub API 返回的 commit 转换为 CommitEntry，
+// 过滤掉 bot 提交、不符合提交信息规则的提交以及日期无法解析的提交，将作者归并为规范身份并分类。
+// 保留的提交使用规范化后的提交信息，分类仍基于原始信息，以便利用 fix: 等前缀。
+// 关联到 PR 的提交计入 PR 作者名下，merge commit 使用 PR 标题作为提交信息。
+// 除 merge commit 外，只为通过过滤的提交查询 PR。
+func toCommitEntries(orgName, repo string, repoCommits []github.Commit, opts collectOptions) []CommitEntry {
+	entries := make([]CommitEntry, 0, len(repoCommits))
+	for _, commit := range repoCommits {
+		authorName := commit.Commit.Author.Name
+		authorLogin, authorType := "", ""
+		if commit.Author != nil {
+			authorLogin, authorType = commit.Author.Login, commit.Author.Type
+		}
+		if isBot, reason := opts.bots.Check(authorName, authorLogin, authorType); isBot {
+			// 过滤掉 bot 提交，比如 actions 自动生成的就不需要计数，但记录下来用于统计自动化操作
+			opts.drops.record(droppedCommit{Repo: repo, SHA: commit.SHA, Message: strings.Split(commit.Commit.Message, "\n")[0], Rule: ruleBot, Reason: reason})
+			continue
+		}
+		original := commit.Commit.Message
+		var pr *github.PullRequest
+		_, isMerge := pullRequestRef(original)
+		if isMerge {
+			// merge commit 的首行是英文模板，先换成 PR 标题再过滤
+			if pr = resolvePullRequest(orgName, repo, commit, opts); pr != nil {
+				original = pr.Title
+			}
+		}
+		msg := original
+		if opts.filter != nil {
+			var decision message.Decision
+			msg, decision = opts.filter.Check(original)
+			if !decision.Keep {
+				opts.drops.record(droppedCommit{Repo: repo, SHA: commit.SHA, Message: strings.Split(original, "\n")[0], Rule: decision.Rule, Reason: decision.Reason})
+				continue // 过滤掉代码提交、自动生成的提交信息等
+			}
+		}
+		if !isMerge {
+			pr = resolvePullRequest(orgName, repo, commit, opts) // 只为保留的提交查询 PR，节省 API 调用
+		}
+
+		date, err := time.Parse(time.RFC3339, commit.Commit.Author.Date)
+		if err != nil {
+			continue
+		}
+		date = date.In(utils.BeijingTimeZone) // Convert to BJT
+		id := opts.resolver.Resolve(authorName, commit.Commit.Author.Email, authorLogin)
+		if pr != nil && pr.User.Login != "" {
+			if isBot, _ := opts.bots.Check("", pr.User.Login, pr.User.Type); !isBot {
+				id = opts.resolver.Resolve("", "", pr.User.Login) // 计入 PR 作者而非合并者
+				if id.Name == "" {
+					id.Name = id.Login
+				}
+			}
+		}
+
+		entry := CommitEntry{
+			AuthorName:   id.Name,
+			AuthorLogin:  id.Login,
+			AuthorAvatar: id.Avatar,
+			CoAuthors:    parseCoAuthors(commit.Commit.Message, id, opts.resolver, opts.bots),
+			Date:         date,
+			Message:      msg,
+			RepoName:     repo,
+			SHA:          commit.SHA,
+			URL:          commit.HTMLURL,
+		}
+		if entry.URL == "" && commit.SHA != "" {
+			entry.URL = fmt.Sprintf("https://github.com/%s/%s/commit/%s", orgName, repo, commit.SHA)
+		}
+		if pr != nil {
+			entry.PRNumber = pr.Number
+			entry.PRURL = pr.HTMLURL
+		}
+		if opts.fetchFiles && commit.SHA != "" {
+			files, err := github.GetCommitFiles(orgName, repo, commit.SHA)
+			if err != nil {
+				log.Printf("Failed to fetch files of %s@%s: %v", repo, commit.SHA, err)
+			}
+			entry.Files = files
+		}
+		entry.Category = opts.categorizer.Categorize(original, entry.Files)
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/message"
+)
+
+// mustCommits 从 GitHub API 风格的 JSON 构造 commit 列表。
+func mustCommits(t *testing.T, raw string) []github.Commit {
+	t.Helper()
+	var commits []github.Commit
+	if err := json.Unmarshal([]byte(raw), &commits); err != nil {
+		t.Fatalf("failed to parse commits: %v", err)
+	}
+	return commits
+}
+
+func TestToCommitEntries_ResolvesAliases(t *testing.T) {
+	commits := mustCommits(t, `[
+		{"commit": {"author": {"name": "zs-laptop", "email": "zs@example.com", "date": "2026-02-13T02:00:00Z"}, "message": "上传试卷"}, "author": null},
+		{"commit": {"author": {"name": "Zhang San", "email": "other@example.com", "date": "2026-02-13T03:00:00Z"}, "message": "添加课件"}, "author": {"login": "zhangsan"}},
+		{"commit": {"author": {"name": "github-actions[bot]", "email": "", "date": "2026-02-13T03:00:00Z"}, "message": "自动更新"}, "author": null},
+		{"commit": {"author": {"name": "王五", "email": "", "date": "2026-02-13T03:00:00Z"}, "message": "update"}, "author": null}
+	]`)
+	opts := newCollectOptions(&config.Config{Aliases: []identity.Alias{{
+		Name:   "张三",
+		Login:  "zhangsan",
+		Emails: []string{"zs@example.com"},
+	}}}, false)
+
+	entries := toCommitEntries("HITSZ-OpenAuto", "EE3001", commits, opts)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries after filtering, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.AuthorName != "张三" || e.AuthorLogin != "zhangsan" {
+			t.Errorf("expected both commits credited to 张三/zhangsan, got %q/%q", e.AuthorName, e.AuthorLogin)
+		}
+		if e.Date.Hour() < 10 {
+			t.Errorf("expected date converted to BJT, got %s", e.Date)
+		}
+	}
+}
+
+func TestRenderAuthor_LinksProfile(t *testing.T) {
+	if got := renderAuthor(i18n.Default, CommitEntry{AuthorName: "张三", AuthorLogin: "zhangsan"}); got != "[张三](https://github.com/zhangsan)" {
+		t.Errorf("renderAuthor() = %q", got)
+	}
+	if got := renderAuthor(i18n.Default, CommitEntry{AuthorName: "<b>匿名</b>"}); strings.Contains(got, "<b>") || strings.Contains(got, "](") {
+		t.Errorf("expected plain escaped name without login, got %q", got)
+	}
+}
+
+func TestToCommitEntries_NormalizesAndRecordsDrops(t *testing.T) {
+	commits := mustCommits(t, `[
+		{"sha": "a1", "commit": {"author": {"name": "张三", "email": "", "date": "2026-02-13T02:00:00Z"}, "message": "fix: [EE3001] 修正答案"}, "author": null},
+		{"sha": "a2", "commit": {"author": {"name": "李四", "email": "", "date": "2026-02-13T02:00:00Z"}, "message": "Add 23AISZ Review Materials"}, "author": null},
+		{"sha": "a3", "commit": {"author": {"name": "李四", "email": "", "date": "2026-02-13T02:00:00Z"}, "message": "Update README.md"}, "author": null}
+	]`)
+	cfg := &config.Config{}
+	cfg.Messages.Strip = []string{message.StripConventional, message.StripTags, message.StripEmoji}
+	cfg.Messages.Languages = []string{"zh", "en"}
+	cfg.Messages.MinRatio = 0.5
+	cfg.Messages.DropLog = filepath.Join(t.TempDir(), "dropped.json")
+	opts := newCollectOptions(cfg, false)
+
+	entries := toCommitEntries("HITSZ-OpenAuto", "EE3001", commits, opts)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Message != "修正答案" || entries[0].Category != categorize.Correction {
+		t.Errorf("entry = %q (%s), want normalized message categorized as correction", entries[0].Message, entries[0].Category)
+	}
+
+	opts.drops.flush()
+	data, err := os.ReadFile(cfg.Messages.DropLog)
+	if err != nil {
+		t.Fatalf("failed to read drop log: %v", err)
+	}
+	var dropped []droppedCommit
+	if err := json.Unmarshal(data, &dropped); err != nil {
+		t.Fatalf("failed to parse drop log: %v", err)
+	}
+	if len(dropped) != 1 || dropped[0].SHA != "a3" || dropped[0].Rule != message.RuleGenerated || dropped[0].Reason == "" {
+		t.Errorf("dropped = %+v, want a3 dropped as generated with a reason", dropped)
+	}
+}
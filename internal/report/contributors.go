@@ -0,0 +1,392 @@
+// 贡献者页面与排行榜
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+const (
+	contributorsDir = "news/contributors"
+	leaderboardSize = 20 // 每个排行榜最多展示的人数
+)
+
+// Contributor 汇总单个贡献者（以 GitHub 登录名区分）的全部有效提交。
+type Contributor struct {
+	Login   string
+	Name    string        // 最近一次提交使用的作者名
+	Avatar  string        // 头像地址
+	Commits []CommitEntry // 按时间从新到旧排序
+}
+
+// rankEntry 是排行榜中的一行。
+type rankEntry struct {
+	Rank    int
+	Login   string
+	Name    string
+	Commits int
+	Courses int
+}
+
+// Contributors 根据所有公开仓库的完整提交历史，生成 news/contributors/<login>/index.md 个人页面
+// 以及 news/contributors/index.md 排行榜。opt_out 中的贡献者不会出现在任何页面中。
+func Contributors(orgName string, publicRepos map[string]struct{}, cfg *config.Config) error {
+	histories := collectHistory(orgName, publicRepos, historyGoroutineLimit, newCollectOptions(cfg, false))
+
+	var commits []CommitEntry
+	repoNames := make(map[string]string)
+	for _, history := range histories {
+		commits = append(commits, history.Commits...)
+		if name := history.Meta.Name; name != "" {
+			repoNames[history.Repo] = name
+		}
+	}
+
+	optOut := make(map[string]struct{}, len(cfg.Contributors.OptOut))
+	for _, login := range cfg.Contributors.OptOut {
+		optOut[strings.ToLower(strings.TrimSpace(login))] = struct{}{}
+	}
+	contributors := groupContributors(commits, optOut)
+	log.Printf("Grouped %d contributors from %d commits", len(contributors), len(commits))
+
+	var errs []error
+	updated := 0
+	for _, c := range contributors {
+		changed, err := UpdateContributorPage(filepath.Join(contributorsDir, c.Login, "index.md"), orgName, c, repoNames)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Login, err))
+			continue
+		}
+		if changed {
+			updated++
+		}
+	}
+	for login := range optOut {
+		// 已经生成过页面的贡献者选择退出后，删除其页面
+		if err := removeContributorPage(login); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	log.Printf("Contributor pages complete, %d of %d updated", updated, len(contributors))
+
+	now := time.Now().In(utils.BeijingTimeZone)
+	if _, err := UpdateLeaderboard(filepath.Join(contributorsDir, "index.md"), contributors, now); err != nil {
+		errs = append(errs, fmt.Errorf("leaderboard: %w", err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to update contributor pages: %w", err)
+	}
+	return nil
+}
+
+// groupContributors 按 GitHub 登录名聚合提交，共同作者同样计入，跳过没有登录名的身份以及选择退出的贡献者。
+// 返回结果按登录名排序。
+func groupContributors(commits []CommitEntry, optOut map[string]struct{}) []Contributor {
+	byLogin := make(map[string]*Contributor)
+	latest := make(map[string]CommitEntry) // 登录名 -> 最近一次提交，用于确定展示名和头像
+	identities := make(map[string]identity.Identity)
+	skipped := 0
+	for _, commit := range commits {
+		for _, id := range commitCredits(commit) {
+			if id.Login == "" {
+				skipped++ // 没有关联 GitHub 账号的身份无法生成个人页面
+				continue
+			}
+			if _, ok := optOut[strings.ToLower(id.Login)]; ok {
+				continue
+			}
+			c, ok := byLogin[id.Login]
+			if !ok {
+				c = &Contributor{Login: id.Login}
+				byLogin[id.Login] = c
+			}
+			c.Commits = append(c.Commits, commit)
+			if prev, ok := latest[id.Login]; !ok || commit.Date.After(prev.Date) {
+				latest[id.Login] = commit
+				identities[id.Login] = id
+			}
+		}
+	}
+	if skipped > 0 {
+		log.Printf("Skipped %d commit credits without GitHub login", skipped)
+	}
+
+	contributors := make([]Contributor, 0, len(byLogin))
+	for _, c := range byLogin {
+		sortCommits(c.Commits)
+		id := identities[c.Login]
+		c.Name = id.Name
+		c.Avatar = id.AvatarURL()
+		contributors = append(contributors, *c)
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		return contributors[i].Login < contributors[j].Login
+	})
+	return contributors
+}
+
+// UpdateContributorPage 渲染贡献者个人页面并写入 path。
+// 如果已有页面与新内容实质相同则跳过写入，返回值表示文件是否被重写。
+func UpdateContributorPage(path string, orgName string, c Contributor, repoNames map[string]string) (bool, error) {
+	body := buildContributorBody(orgName, c, repoNames)
+	fm, err := utils.GenerateFrontMatter(
+		c.Name+" 的贡献",
+		time.Now().In(utils.BeijingTimeZone).Format("2006-01-02"),
+		fmt.Sprintf("%s 在 HITSZ-OpenAuto 各课程仓库中的贡献记录", c.Name),
+		[]utils.Author{{
+			Name:  c.Name,
+			Link:  "https://github.com/" + c.Login,
+			Image: c.Avatar,
+		}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate front matter: %w", err)
+	}
+	return writeIfChanged(path, fm, body)
+}
+
+// buildContributorBody 渲染贡献者个人页面的主体：概览、按课程统计和按月分组的提交记录。
+func buildContributorBody(orgName string, c Contributor, repoNames map[string]string) string {
+	sortCommits(c.Commits)
+
+	type courseStat struct {
+		Repo    string
+		Commits int
+		Last    time.Time
+	}
+	stats := make(map[string]*courseStat)
+	for _, commit := range c.Commits {
+		s, ok := stats[commit.RepoName]
+		if !ok {
+			s = &courseStat{Repo: commit.RepoName, Last: commit.Date}
+			stats[commit.RepoName] = s
+		}
+		s.Commits++
+	}
+	courses := make([]*courseStat, 0, len(stats))
+	for _, s := range stats {
+		courses = append(courses, s)
+	}
+	sort.Slice(courses, func(i, j int) bool {
+		if courses[i].Commits != courses[j].Commits {
+			return courses[i].Commits > courses[j].Commits
+		}
+		return courses[i].Repo < courses[j].Repo
+	})
+
+	var buf strings.Builder
+
+	buf.WriteString("## 概览\n\n")
+	fmt.Fprintf(&buf, "- **GitHub**: [@%s](https://github.com/%s)\n", utils.SanitizeLinkLabel(c.Login), c.Login)
+	fmt.Fprintf(&buf, "- **累计提交**: %d\n", len(c.Commits))
+	fmt.Fprintf(&buf, "- **参与课程**: %d\n", len(courses))
+	if len(c.Commits) > 0 {
+		fmt.Fprintf(&buf, "- **首次贡献**: %s\n", c.Commits[len(c.Commits)-1].Date.Format("2006-01-02"))
+		fmt.Fprintf(&buf, "- **最近贡献**: %s\n", c.Commits[0].Date.Format("2006-01-02"))
+	}
+	buf.WriteString("\n")
+
+	buf.WriteString("## 按课程\n\n")
+	buf.WriteString("| 课程 | 提交数 | 最近贡献 |\n")
+	buf.WriteString("| --- | --- | --- |\n")
+	for _, s := range courses {
+		fmt.Fprintf(&buf, "| [%s](https://github.com/%s/%s) | %d | %s |\n",
+			utils.SanitizeTableLabel(courseTitle(s.Repo, repoNames)),
+			orgName, s.Repo, s.Commits, s.Last.Format("2006-01-02"))
+	}
+	buf.WriteString("\n")
+
+	buf.WriteString("## 按时间\n\n")
+	var prevMonth string // 用于检测月份变化，按月分组显示
+	for _, commit := range c.Commits {
+		month := commit.Date.Format("2006-01")
+		if month != prevMonth {
+			fmt.Fprintf(&buf, "### %d 年 %d 月\n\n", commit.Date.Year(), commit.Date.Month())
+			prevMonth = month
+		}
+		title := utils.SanitizeLinkLabel(courseTitle(commit.RepoName, repoNames))
+		message := renderMessage(commit)
+		fmt.Fprintf(&buf, "- %d.%d [%s](https://github.com/%s/%s)：%s\n\n",
+			commit.Date.Month(), commit.Date.Day(), title, orgName, commit.RepoName, message)
+	}
+
+	return buf.String()
+}
+
+// UpdateLeaderboard 渲染本周、本学期和累计三个排行榜并写入 path。
+// 如果已有页面与新内容实质相同则跳过写入，返回值表示文件是否被重写。
+func UpdateLeaderboard(path string, contributors []Contributor, now time.Time) (bool, error) {
+	body := buildLeaderboardBody(contributors, now)
+	fm, err := utils.GenerateFrontMatter(
+		"贡献者排行榜",
+		now.Format("2006-01-02"),
+		"感谢每一位为 HITSZ-OpenAuto 上传资料的同学",
+		[]utils.Author{{
+			Name:  "github-actions[bot]",
+			Link:  "https://github.com/features/actions",
+			Image: "https://avatars.githubusercontent.com/in/15368",
+		}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate front matter: %w", err)
+	}
+	return writeIfChanged(path, fm, body)
+}
+
+// buildLeaderboardBody 渲染排行榜页面主体。now 应为北京时间。
+func buildLeaderboardBody(contributors []Contributor, now time.Time) string {
+	weekStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -7)
+	semStart, semName := semesterStart(now)
+
+	var buf strings.Builder
+
+	buf.WriteString("## 本周排行\n\n")
+	fmt.Fprintf(&buf, "统计区间：%s 至 %s\n\n", weekStart.Format("2006-01-02"), now.Format("2006-01-02"))
+	writeRanking(&buf, rankContributors(contributors, weekStart))
+
+	fmt.Fprintf(&buf, "## 本学期排行（%s）\n\n", semName)
+	fmt.Fprintf(&buf, "统计区间：%s 至 %s\n\n", semStart.Format("2006-01-02"), now.Format("2006-01-02"))
+	writeRanking(&buf, rankContributors(contributors, semStart))
+
+	buf.WriteString("## 累计排行\n\n")
+	writeRanking(&buf, rankContributors(contributors, time.Time{}))
+
+	return buf.String()
+}
+
+// rankContributors 统计 since 之后（含）每位贡献者的提交数和参与课程数，
+// 按提交数、课程数降序排列，并列者名次相同。最多返回 leaderboardSize 名。
+func rankContributors(contributors []Contributor, since time.Time) []rankEntry {
+	entries := make([]rankEntry, 0, len(contributors))
+	for _, c := range contributors {
+		count := 0
+		repos := make(map[string]struct{})
+		for _, commit := range c.Commits {
+			if commit.Date.Before(since) {
+				continue
+			}
+			count++
+			repos[commit.RepoName] = struct{}{}
+		}
+		if count == 0 {
+			continue
+		}
+		entries = append(entries, rankEntry{Login: c.Login, Name: c.Name, Commits: count, Courses: len(repos)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Commits != entries[j].Commits {
+			return entries[i].Commits > entries[j].Commits
+		}
+		if entries[i].Courses != entries[j].Courses {
+			return entries[i].Courses > entries[j].Courses
+		}
+		return entries[i].Login < entries[j].Login
+	})
+	for i := range entries {
+		if i > 0 && entries[i].Commits == entries[i-1].Commits && entries[i].Courses == entries[i-1].Courses {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	if len(entries) > leaderboardSize {
+		entries = entries[:leaderboardSize]
+	}
+	return entries
+}
+
+// writeRanking 将排行榜渲染为 Markdown 表格，贡献者名链接到其个人页面。
+func writeRanking(buf *strings.Builder, entries []rankEntry) {
+	if len(entries) == 0 {
+		buf.WriteString("暂无贡献\n\n")
+		return
+	}
+	buf.WriteString("| 排名 | 贡献者 | 提交数 | 参与课程 |\n")
+	buf.WriteString("| --- | --- | --- | --- |\n")
+	for _, e := range entries {
+		fmt.Fprintf(buf, "| %d | [%s](/news/contributors/%s) | %d | %d |\n",
+			e.Rank, utils.SanitizeTableLabel(e.Name), e.Login, e.Commits, e.Courses)
+	}
+	buf.WriteString("\n")
+}
+
+// semesterStart 返回 now 所在学期的起始时间和名称。
+// 春季学期为 2 月至 7 月，秋季学期为 8 月至次年 1 月。
+func semesterStart(now time.Time) (time.Time, string) {
+	year := now.Year()
+	switch {
+	case now.Month() >= time.August:
+		return time.Date(year, time.August, 1, 0, 0, 0, 0, now.Location()), fmt.Sprintf("%d 秋季学期", year)
+	case now.Month() >= time.February:
+		return time.Date(year, time.February, 1, 0, 0, 0, 0, now.Location()), fmt.Sprintf("%d 春季学期", year)
+	default:
+		return time.Date(year-1, time.August, 1, 0, 0, 0, 0, now.Location()), fmt.Sprintf("%d 秋季学期", year-1)
+	}
+}
+
+// courseTitle 返回仓库对应的课程名，无课程名时回退为仓库名。
+func courseTitle(repo string, repoNames map[string]string) string {
+	if title := repoNames[repo]; title != "" {
+		return title
+	}
+	return repo
+}
+
+// removeContributorPage 删除选择退出的贡献者已生成的页面目录，目录不存在时不做任何操作。
+func removeContributorPage(login string) error {
+	entries, err := os.ReadDir(contributorsDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		// 登录名大小写不敏感，目录名以首次生成时的大小写为准
+		if entry.IsDir() && strings.EqualFold(entry.Name(), login) {
+			log.Printf("Removing page of opted-out contributor %s", entry.Name())
+			return os.RemoveAll(filepath.Join(contributorsDir, entry.Name()))
+		}
+	}
+	return nil
+}
+
+// writeIfChanged 将 front matter 与 body 写入 path，若已有文件主体与 body 实质相同则跳过。
+// 返回值表示文件是否被重写。
+func writeIfChanged(path, frontMatter, body string) (bool, error) {
+	oldContent, readErr := os.ReadFile(path)
+	if readErr == nil {
+		if isSubstantivelyEqual(string(oldContent), body) {
+			return false, nil
+		}
+	} else if !errors.Is(readErr, fs.ErrNotExist) {
+		return false, fmt.Errorf("failed to read existing page %q: %w", path, readErr)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, fmt.Errorf("failed to create directory for %q: %w", path, err)
+	}
+
+	var final strings.Builder
+	final.WriteString("---\n")
+	final.WriteString(frontMatter)
+	final.WriteString("---\n\n")
+	final.WriteString(body)
+
+	if err := os.WriteFile(path, []byte(final.String()), 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
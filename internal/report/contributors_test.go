@@ -0,0 +1,127 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupContributors(t *testing.T) {
+	commits := []CommitEntry{
+		{AuthorName: "Old Name", AuthorLogin: "alice", Date: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Message: "旧提交", RepoName: "EE3001"},
+		{AuthorName: "Alice", AuthorLogin: "alice", Date: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), Message: "新提交", RepoName: "COMP3052"},
+		{AuthorName: "Bob", AuthorLogin: "Bob", Date: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), Message: "提交", RepoName: "EE3001"},
+		{AuthorName: "无账号", Date: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), Message: "提交", RepoName: "EE3001"},
+	}
+
+	got := groupContributors(commits, map[string]struct{}{"bob": {}})
+	if len(got) != 1 {
+		t.Fatalf("expected 1 contributor after opt-out and login filtering, got %d", len(got))
+	}
+	if got[0].Login != "alice" || got[0].Name != "Alice" {
+		t.Errorf("got contributor %q (%q), want alice (Alice)", got[0].Login, got[0].Name)
+	}
+	if len(got[0].Commits) != 2 || got[0].Commits[0].Message != "新提交" {
+		t.Errorf("expected commits sorted newest first, got %+v", got[0].Commits)
+	}
+}
+
+func TestRankContributors(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC) }
+	contributors := []Contributor{
+		{Login: "a", Name: "A", Commits: []CommitEntry{{Date: day(10), RepoName: "r1"}, {Date: day(1), RepoName: "r2"}}},
+		{Login: "b", Name: "B", Commits: []CommitEntry{{Date: day(10), RepoName: "r1"}, {Date: day(9), RepoName: "r2"}}},
+		{Login: "c", Name: "C", Commits: []CommitEntry{{Date: day(10), RepoName: "r1"}, {Date: day(9), RepoName: "r1"}}},
+		{Login: "d", Name: "D", Commits: []CommitEntry{{Date: day(10), RepoName: "r1"}}},
+	}
+
+	all := rankContributors(contributors, time.Time{})
+	wantOrder := []string{"a", "b", "c", "d"}
+	wantRank := []int{1, 1, 3, 4}
+	if len(all) != len(wantOrder) {
+		t.Fatalf("expected %d entries, got %d", len(wantOrder), len(all))
+	}
+	for i := range all {
+		if all[i].Login != wantOrder[i] || all[i].Rank != wantRank[i] {
+			t.Errorf("entry %d = %s#%d, want %s#%d", i, all[i].Login, all[i].Rank, wantOrder[i], wantRank[i])
+		}
+	}
+
+	recent := rankContributors(contributors, day(5))
+	if recent[0].Login != "b" || recent[0].Commits != 2 {
+		t.Errorf("expected b to lead since day 5, got %+v", recent[0])
+	}
+	for _, e := range recent {
+		if e.Login == "a" && e.Commits != 1 {
+			t.Errorf("expected commits before since to be ignored, got %+v", e)
+		}
+	}
+}
+
+func TestSemesterStart(t *testing.T) {
+	tests := []struct {
+		now       time.Time
+		wantStart time.Time
+		wantName  string
+	}{
+		{time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), "2026 春季学期"},
+		{time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), "2026 秋季学期"},
+		{time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), "2025 秋季学期"},
+	}
+	for _, tt := range tests {
+		start, name := semesterStart(tt.now)
+		if !start.Equal(tt.wantStart) || name != tt.wantName {
+			t.Errorf("semesterStart(%s) = %s %q, want %s %q", tt.now, start, name, tt.wantStart, tt.wantName)
+		}
+	}
+}
+
+func TestBuildContributorBody(t *testing.T) {
+	c := Contributor{
+		Login: "alice",
+		Name:  "Alice",
+		Commits: []CommitEntry{
+			{AuthorName: "Alice", Date: time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC), Message: "上传试卷", RepoName: "EE3001"},
+			{AuthorName: "Alice", Date: time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC), Message: "添加课件", RepoName: "EE3001"},
+			{AuthorName: "Alice", Date: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), Message: "<b>修复</b>", RepoName: "COMP3052"},
+		},
+	}
+	body := buildContributorBody("HITSZ-OpenAuto", c, map[string]string{"EE3001": "电路|原理"})
+
+	for _, want := range []string{
+		"- **GitHub**: [@alice](https://github.com/alice)",
+		"- **累计提交**: 3",
+		"- **参与课程**: 2",
+		"- **首次贡献**: 2026-01-14",
+		"- **最近贡献**: 2026-02-03",
+		"| [电路\\|原理](https://github.com/HITSZ-OpenAuto/EE3001) | 2 | 2026-02-03 |",
+		"| [COMP3052](https://github.com/HITSZ-OpenAuto/COMP3052) | 1 | 2026-02-01 |",
+		"### 2026 年 2 月",
+		"&lt;b&gt;修复&lt;/b&gt;",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("buildContributorBody() missing %q\nGot:\n%s", want, body)
+		}
+	}
+}
+
+func TestBuildLeaderboardBody(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	contributors := []Contributor{
+		{Login: "alice", Name: "Alice", Commits: []CommitEntry{{Date: now.AddDate(0, 0, -1), RepoName: "EE3001"}}},
+		{Login: "bob", Name: "Bob", Commits: []CommitEntry{{Date: now.AddDate(-1, 0, 0), RepoName: "EE3001"}}},
+	}
+	body := buildLeaderboardBody(contributors, now)
+
+	week := extractSection(body, "## 本周排行")
+	if !strings.Contains(week, "[Alice](/news/contributors/alice)") || strings.Contains(week, "Bob") {
+		t.Errorf("unexpected weekly ranking:\n%s", week)
+	}
+	if !strings.Contains(body, "## 本学期排行（2026 春季学期）") {
+		t.Errorf("missing semester heading:\n%s", body)
+	}
+	all := extractSection(body, "## 累计排行")
+	if !strings.Contains(all, "Alice") || !strings.Contains(all, "Bob") {
+		t.Errorf("expected both contributors in all-time ranking:\n%s", all)
+	}
+}
@@ -0,0 +1,95 @@
+// 课程元数据：解析课程仓库根目录下的 readme.toml
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Teacher 是 readme.toml 中的一位授课教师。
+type Teacher struct {
+	Name string `toml:"name" json:"name"`
+}
+
+// CourseMeta 是 readme.toml 中与报告相关的课程元数据，未出现的字段为零值。
+type CourseMeta struct {
+	Name       string    `toml:"course_name" json:"name"`           // 课程名称
+	Code       string    `toml:"course_code" json:"code,omitempty"` // 课程代码，缺失时使用 repo_name
+	RepoName   string    `toml:"repo_name" json:"repo_name,omitempty"`
+	Category   string    `toml:"category" json:"category,omitempty"`     // 课程类别，如专业核心
+	Credits    float64   `toml:"credit" json:"credit,omitempty"`         // 学分
+	Hours      int       `toml:"hours" json:"hours,omitempty"`           // 学时
+	Semester   string    `toml:"semester" json:"semester,omitempty"`     // 开课学期
+	Department string    `toml:"department" json:"department,omitempty"` // 开课院系
+	Teachers   []Teacher `toml:"teachers" json:"teachers,omitempty"`
+	Lecturers  []Teacher `toml:"lecturers" json:"-"` // 部分仓库使用 lecturers 记录教师，解析后合并到 Teachers
+}
+
+// ParseCourseMeta 解析 readme.toml 的内容。语法错误会附带行列号；
+// 语法正确但内容不合法（缺少课程名、学分为负、教师缺少姓名）时返回解析结果和校验错误。
+func ParseCourseMeta(text string) (CourseMeta, error) {
+	var meta CourseMeta
+	if err := toml.Unmarshal([]byte(text), &meta); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			row, col := decodeErr.Position()
+			return CourseMeta{}, fmt.Errorf("failed to parse readme.toml at line %d, column %d: %w", row, col, err)
+		}
+		return CourseMeta{}, fmt.Errorf("failed to parse readme.toml: %w", err)
+	}
+	meta.Name = strings.TrimSpace(meta.Name)
+	if meta.Code == "" {
+		meta.Code = meta.RepoName
+	}
+	meta.Teachers = append(meta.Teachers, meta.Lecturers...)
+	meta.Lecturers = nil
+	return meta, meta.Validate()
+}
+
+// Validate 检查元数据是否完整合法，返回全部问题。
+func (m CourseMeta) Validate() error {
+	var errs []error
+	if m.Name == "" {
+		errs = append(errs, errors.New("course_name is missing"))
+	}
+	if m.Credits < 0 {
+		errs = append(errs, fmt.Errorf("credit %v must not be negative", m.Credits))
+	}
+	if m.Hours < 0 {
+		errs = append(errs, fmt.Errorf("hours %d must not be negative", m.Hours))
+	}
+	for i, t := range m.Teachers {
+		if strings.TrimSpace(t.Name) == "" {
+			errs = append(errs, fmt.Errorf("teacher #%d has no name", i+1))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid readme.toml: %w", err)
+	}
+	return nil
+}
+
+// TeacherNames 返回授课教师姓名列表，跳过空名字。
+func (m CourseMeta) TeacherNames() []string {
+	names := make([]string, 0, len(m.Teachers))
+	for _, t := range m.Teachers {
+		if name := strings.TrimSpace(t.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// courseTitles 从课程元数据中提取 repo 名 -> 课程名的映射，跳过没有课程名的仓库。
+func courseTitles(metas map[string]CourseMeta) map[string]string {
+	titles := make(map[string]string, len(metas))
+	for repo, meta := range metas {
+		if meta.Name != "" {
+			titles[repo] = meta.Name
+		}
+	}
+	return titles
+}
@@ -0,0 +1,221 @@
+// 课程元数据缓存：跨运行保存每个仓库 readme.toml 的 blob SHA、ETag 和解析结果，
+// 只有 readme.toml 发生变化时才重新下载和解析
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+)
+
+const courseMetaCacheFile = "course-meta.json"
+
+// courseMetaEntry 是单个仓库的缓存记录。
+type courseMetaEntry struct {
+	SHA       string     `json:"sha"`             // readme.toml 的 blob SHA
+	ETag      string     `json:"etag,omitempty"`  // 用于条件请求
+	CheckedAt time.Time  `json:"checked_at"`      // 最近一次下载 readme.toml 的时间
+	Meta      CourseMeta `json:"meta"`            // 解析结果，语法错误时为零值
+	Error     string     `json:"error,omitempty"` // 解析或校验错误
+}
+
+// readmeFetcher 获取仓库的 readme.toml，etag 非空且文件未变化时返回 github.ErrNotModified。
+type readmeFetcher func(orgName, repoName, etag string) (github.ReadmeToml, error)
+
+// courseMetaCache 并发安全地缓存课程元数据。path 为空时只在内存中缓存。
+type courseMetaCache struct {
+	mu      sync.Mutex
+	path    string
+	fetch   readmeFetcher
+	now     func() time.Time
+	dirty   bool
+	checked map[string]time.Time       // 本次运行中条件请求确认未变化的时间，不写盘
+	Courses map[string]courseMetaEntry `json:"courses"` // repo 名 -> 缓存记录
+}
+
+func newCourseMetaCache(path string, fetch readmeFetcher) *courseMetaCache {
+	return &courseMetaCache{
+		path:    path,
+		fetch:   fetch,
+		now:     time.Now,
+		checked: make(map[string]time.Time),
+		Courses: make(map[string]courseMetaEntry),
+	}
+}
+
+// loadCourseMetaCache 读取 cacheDir 下的缓存文件。文件不存在或损坏时返回空缓存，损坏时会在保存时覆盖。
+func loadCourseMetaCache(cacheDir string, fetch readmeFetcher) *courseMetaCache {
+	if cacheDir == "" {
+		return newCourseMetaCache("", fetch)
+	}
+	cache := newCourseMetaCache(filepath.Join(cacheDir, courseMetaCacheFile), fetch)
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Failed to read course meta cache: %v", err)
+		}
+		return cache
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		log.Printf("Failed to parse course meta cache %q, rebuilding: %v", cache.path, err)
+		cache.dirty = true
+	}
+	if cache.Courses == nil {
+		cache.Courses = make(map[string]courseMetaEntry)
+	}
+	return cache
+}
+
+// lookup 返回仓库的课程元数据，错误语义与 ParseCourseMeta 相同。
+// untouched 为 true 表示调用方确认 since 之后的提交都没有修改 readme.toml，
+// 此时若缓存在 since 之后下载或确认过，直接使用缓存；否则带 ETag 发起条件请求。
+// 缓存文件随报告一起提交，304 只在内存中记录确认时间，内容未变化时不改写文件。
+func (c *courseMetaCache) lookup(orgName, repo string, since time.Time, untouched bool) (CourseMeta, error) {
+	c.mu.Lock()
+	entry, cached := c.Courses[repo]
+	checkedAt := entry.CheckedAt
+	if t, ok := c.checked[repo]; ok && t.After(checkedAt) {
+		checkedAt = t
+	}
+	c.mu.Unlock()
+	if cached && untouched && !checkedAt.Before(since) {
+		return entry.Meta, entry.err()
+	}
+
+	etag := ""
+	if cached {
+		etag = entry.ETag
+	}
+	file, err := c.fetch(orgName, repo, etag)
+	if errors.Is(err, github.ErrNotModified) && cached {
+		c.mu.Lock()
+		c.checked[repo] = c.now()
+		c.mu.Unlock()
+		return entry.Meta, entry.err()
+	}
+	if err != nil {
+		// 请求失败时退回到缓存的元数据，调用方仍会记录错误
+		return entry.Meta, err
+	}
+
+	c.store(repo, file)
+	return c.entry(repo)
+}
+
+// store 解析下载到的 readme.toml 并写入缓存。
+func (c *courseMetaCache) store(repo string, file github.ReadmeToml) {
+	meta, err := ParseCourseMeta(file.Content)
+	entry := courseMetaEntry{SHA: file.SHA, ETag: file.ETag, CheckedAt: c.now(), Meta: meta}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Courses[repo] = entry
+	c.dirty = true
+}
+
+func (c *courseMetaCache) entry(repo string) (CourseMeta, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.Courses[repo]
+	return entry.Meta, entry.err()
+}
+
+func (e courseMetaEntry) err() error {
+	if e.Error == "" {
+		return nil
+	}
+	return errors.New(e.Error)
+}
+
+// save 在缓存内容变化时写回文件，键按字母序输出以保证内容稳定。
+func (c *courseMetaCache) save() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty || c.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// readmeUntouched 判断一组提交是否确定没有修改 readme.toml。
+// 只有 total 条提交全部被保留且都带有变更文件列表时才能确定，否则返回 false。
+func readmeUntouched(total int, commits []CommitEntry) bool {
+	if len(commits) != total {
+		return false
+	}
+	for _, commit := range commits {
+		if commit.Files == nil {
+			return false
+		}
+		for _, file := range commit.Files {
+			if strings.EqualFold(path.Clean(file), "readme.toml") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RefreshCourseMeta 忽略已有缓存，重新下载所有公开仓库的 readme.toml 并重建缓存文件。
+func RefreshCourseMeta(orgName string, publicRepos map[string]struct{}, cfg *config.Config) error {
+	cache := newCourseMetaCache(filepath.Join(cfg.CacheDir, courseMetaCacheFile), github.GetReadmeToml)
+	cache.dirty = true // 即使没有任何仓库包含 readme.toml，也要覆盖旧缓存
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		failed  int
+		goLimit = make(chan struct{}, historyGoroutineLimit)
+	)
+	for repo := range publicRepos {
+		wg.Add(1)
+		go func(repo string) {
+			defer wg.Done()
+
+			goLimit <- struct{}{}
+			defer func() { <-goLimit }()
+
+			if _, err := cache.lookup(orgName, repo, time.Time{}, false); err != nil {
+				log.Printf("Failed to load readme.toml for %s: %v", repo, err)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(repo)
+	}
+	wg.Wait()
+
+	if err := cache.save(); err != nil {
+		return fmt.Errorf("failed to write course meta cache: %w", err)
+	}
+	log.Printf("Course meta cache rebuilt, %d repos cached, %d failed", len(cache.Courses), failed)
+	return nil
+}
@@ -0,0 +1,109 @@
+package report
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+)
+
+func TestCourseMetaCache_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+
+	var (
+		calls    int
+		lastETag string
+		remote   = github.ReadmeToml{SHA: "sha1", ETag: `"e1"`, Content: `course_name = "电路原理"`}
+	)
+	fetch := func(orgName, repoName, etag string) (github.ReadmeToml, error) {
+		calls++
+		lastETag = etag
+		if etag != "" && etag == remote.ETag {
+			return github.ReadmeToml{}, github.ErrNotModified
+		}
+		return remote, nil
+	}
+
+	cache := loadCourseMetaCache(dir, fetch)
+	cache.now = func() time.Time { return now }
+	meta, err := cache.lookup("HITSZ-OpenAuto", "EE3001", since, true)
+	if err != nil || meta.Name != "电路原理" || calls != 1 {
+		t.Fatalf("first lookup = %+v, %v after %d calls", meta, err, calls)
+	}
+	if err := cache.save(); err != nil {
+		t.Fatalf("save() returned error: %v", err)
+	}
+
+	// 重新加载后，变更文件列表确认未修改时不发请求
+	cache = loadCourseMetaCache(dir, fetch)
+	cache.now = func() time.Time { return now.Add(time.Hour) }
+	if meta, _ := cache.lookup("HITSZ-OpenAuto", "EE3001", since, true); meta.Name != "电路原理" || calls != 1 {
+		t.Errorf("expected cache hit without request, got %+v after %d calls", meta, calls)
+	}
+
+	// 无法确认时发起条件请求，304 只在内存中记录确认时间，不改写缓存文件
+	if meta, _ := cache.lookup("HITSZ-OpenAuto", "EE3001", since, false); meta.Name != "电路原理" || calls != 2 || lastETag != `"e1"` {
+		t.Errorf("expected conditional request with etag, got %+v, etag %q after %d calls", meta, lastETag, calls)
+	}
+	if cache.dirty {
+		t.Errorf("304 response should not mark cache dirty")
+	}
+	if _, err := cache.lookup("HITSZ-OpenAuto", "EE3001", now.Add(30*time.Minute), true); err != nil || calls != 2 {
+		t.Errorf("expected in-memory check time to skip the request, calls = %d, err = %v", calls, err)
+	}
+
+	// 缓存早于窗口起点时即使文件列表未修改也要确认
+	if _, err := cache.lookup("HITSZ-OpenAuto", "EE3001", now.Add(2*time.Hour), true); err != nil || calls != 3 {
+		t.Errorf("expected stale entry to be revalidated, calls = %d, err = %v", calls, err)
+	}
+
+	// readme.toml 变化后重新解析，校验错误和解析结果一起缓存
+	remote = github.ReadmeToml{SHA: "sha2", ETag: `"e2"`, Content: `credit = 2`}
+	meta, err = cache.lookup("HITSZ-OpenAuto", "EE3001", since, false)
+	if err == nil || meta.Credits != 2 || !cache.dirty {
+		t.Errorf("expected updated meta with validation error, got %+v, %v (dirty %v)", meta, err, cache.dirty)
+	}
+	if got := cache.Courses["EE3001"].SHA; got != "sha2" {
+		t.Errorf("cached SHA = %q, want sha2", got)
+	}
+}
+
+func TestCourseMetaCache_FallsBackOnError(t *testing.T) {
+	cache := newCourseMetaCache(filepath.Join(t.TempDir(), courseMetaCacheFile), func(string, string, string) (github.ReadmeToml, error) {
+		return github.ReadmeToml{}, errors.New("rate limited")
+	})
+	cache.Courses["EE3001"] = courseMetaEntry{SHA: "sha1", ETag: `"e1"`, Meta: CourseMeta{Name: "电路原理"}}
+
+	meta, err := cache.lookup("HITSZ-OpenAuto", "EE3001", time.Time{}, false)
+	if err == nil || meta.Name != "电路原理" {
+		t.Errorf("expected cached meta with request error, got %+v, %v", meta, err)
+	}
+	if meta, err := cache.lookup("HITSZ-OpenAuto", "MATH1002", time.Time{}, false); err == nil || meta.Name != "" {
+		t.Errorf("expected error for uncached repo, got %+v, %v", meta, err)
+	}
+}
+
+func TestReadmeUntouched(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		commits []CommitEntry
+		want    bool
+	}{
+		{"all files known", 2, []CommitEntry{{Files: []string{"README.md"}}, {Files: []string{}}}, true},
+		{"readme.toml changed", 1, []CommitEntry{{Files: []string{"./readme.toml"}}}, false},
+		{"files unknown", 1, []CommitEntry{{}}, false},
+		{"filtered commits", 2, []CommitEntry{{Files: []string{"README.md"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readmeUntouched(tt.total, tt.commits); got != tt.want {
+				t.Errorf("readmeUntouched() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// 课程动态页，为每个公开仓库维护一份按月分组的更新日志
+package report
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	historyGoroutineLimit = 40 // 课程页和贡献者页需要拉取完整提交历史，并发数比日报更保守
+	coursesDir            = "news/courses"
+)
+
+// courseInfoField 是课程信息段中的一行。
+type courseInfoField struct {
+	Label string
+	Value string
+}
+
+// courseInfoFields 返回课程信息段展示的元数据字段，按展示顺序排列，省略空值。
+func courseInfoFields(meta CourseMeta) []courseInfoField {
+	fields := []courseInfoField{
+		{"课程代码", meta.Code},
+		{"课程类别", meta.Category},
+	}
+	if meta.Credits > 0 {
+		fields = append(fields, courseInfoField{"学分", strconv.FormatFloat(meta.Credits, 'f', -1, 64)})
+	}
+	if meta.Hours > 0 {
+		fields = append(fields, courseInfoField{"学时", strconv.Itoa(meta.Hours)})
+	}
+	fields = append(fields,
+		courseInfoField{"开课学期", meta.Semester},
+		courseInfoField{"开课院系", meta.Department},
+		courseInfoField{"授课教师", strings.Join(meta.TeacherNames(), "、")},
+	)
+
+	nonEmpty := fields[:0]
+	for _, f := range fields {
+		if f.Value != "" {
+			nonEmpty = append(nonEmpty, f)
+		}
+	}
+	return nonEmpty
+}
+
+// CoursePage 保存生成单个课程动态页所需的全部数据。
+type CoursePage struct {
+	Repo    string        // 仓库名，如 EE3001
+	Meta    CourseMeta    // readme.toml 中的课程元数据
+	Commits []CommitEntry // 过滤 bot 后的完整提交历史
+	Issues  []github.Item // 该仓库下待解决的 issues
+	PRs     []github.Item // 该仓库下待合并的 pull requests
+}
+
+// Courses 为每个公开仓库生成或更新 news/courses/<repo>/index.md。
+func Courses(orgName string, publicRepos map[string]struct{}, cfg *config.Config) error {
+	issues, err := github.SearchIssues(orgName, 500)
+	if err != nil {
+		return fmt.Errorf("failed to get issues: %w", err)
+	}
+	prs, err := github.SearchPullRequests(orgName, 500)
+	if err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+	issues = filterBracketedIssues(filterByPublicRepos(issues, publicRepos))
+	prs = filterByPublicRepos(prs, publicRepos)
+	log.Printf("Fetched issues=%d, pull requests=%d", len(issues), len(prs))
+
+	issuesByRepo := groupItemsByRepo(issues)
+	prsByRepo := groupItemsByRepo(prs)
+
+	var (
+		updated int
+		errs    []error
+	)
+	for _, history := range collectHistory(orgName, publicRepos, historyGoroutineLimit, newCollectOptions(cfg, false)) {
+		page := CoursePage{
+			Repo:    history.Repo,
+			Meta:    history.Meta,
+			Commits: history.Commits,
+			Issues:  issuesByRepo[history.Repo],
+			PRs:     prsByRepo[history.Repo],
+		}
+		path := filepath.Join(coursesDir, history.Repo, "index.md")
+		changed, err := UpdateCoursePage(path, orgName, page)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", history.Repo, err))
+			continue
+		}
+		if changed {
+			updated++
+		}
+	}
+
+	log.Printf("Course pages complete, %d of %d updated", updated, len(publicRepos))
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to update course pages: %w", err)
+	}
+	if updated == 0 {
+		return nil // 没有课程页变化时不刷新索引日期，避免产生无意义的提交
+	}
+	if err := WriteCoursesIndex(filepath.Join(coursesDir, "index.md"), time.Now().In(utils.BeijingTimeZone)); err != nil {
+		return fmt.Errorf("failed to update courses index: %w", err)
+	}
+	return nil
+}
+
+// UpdateCoursePage 渲染课程动态页并写入 path。
+// 如果已有页面与新内容实质相同则跳过写入，返回值表示文件是否被重写。
+func UpdateCoursePage(path string, orgName string, page CoursePage) (bool, error) {
+	body := buildCourseBody(orgName, page)
+	name := courseDisplayName(page)
+	fm, err := utils.GenerateFrontMatter(
+		name+" 更新日志",
+		time.Now().In(utils.BeijingTimeZone).Format("2006-01-02"),
+		fmt.Sprintf("%s（%s）的课程资料更新记录", name, page.Repo),
+		[]utils.Author{{
+			Name:  "github-actions[bot]",
+			Link:  "https://github.com/features/actions",
+			Image: "https://avatars.githubusercontent.com/in/15368",
+		}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate front matter: %w", err)
+	}
+	return writeIfChanged(path, fm, body)
+}
+
+// buildCourseBody 渲染课程动态页的主体：课程信息、按月分组的更新日志、待解决的 Issues 和待合并的 PR。
+func buildCourseBody(orgName string, page CoursePage) string {
+	sortCommits(page.Commits)
+	sortItems(page.Issues)
+	sortItems(page.PRs)
+
+	var buf strings.Builder
+
+	buf.WriteString("## 课程信息\n\n")
+	fmt.Fprintf(&buf, "- **课程名称**: %s\n", utils.SanitizeInlineText(courseDisplayName(page)))
+	for _, field := range courseInfoFields(page.Meta) {
+		fmt.Fprintf(&buf, "- **%s**: %s\n", field.Label, utils.SanitizeInlineText(field.Value))
+	}
+	fmt.Fprintf(&buf, "- **仓库**: [%s/%s](https://github.com/%s/%s)\n\n",
+		utils.SanitizeLinkLabel(orgName), utils.SanitizeLinkLabel(page.Repo), orgName, page.Repo)
+
+	buf.WriteString("## 更新日志\n\n")
+	if len(page.Commits) == 0 {
+		buf.WriteString("暂无更新\n\n")
+	} else {
+		var prevMonth string // 用于检测月份变化，按月分组显示
+		for _, commit := range page.Commits {
+			month := commit.Date.Format("2006-01")
+			if month != prevMonth {
+				fmt.Fprintf(&buf, "### %d 年 %d 月\n\n", commit.Date.Year(), commit.Date.Month())
+				prevMonth = month
+			}
+			author := renderAuthor(i18n.Default, commit)
+			message := renderMessage(commit)
+			fmt.Fprintf(&buf, "- %d.%d %s：%s\n\n", commit.Date.Month(), commit.Date.Day(), author, message)
+		}
+	}
+
+	buf.WriteString("## 待解决的 Issues\n\n")
+	if len(page.Issues) == 0 {
+		buf.WriteString("暂无待解决的 Issues\n\n")
+	} else {
+		buf.WriteString(defaultRenderer.mustExecute(dailyItemsTemplate, page.Issues))
+	}
+
+	buf.WriteString("## 待合并的 Pull Requests\n\n")
+	if len(page.PRs) == 0 {
+		buf.WriteString("暂无待合并的 Pull Requests\n\n")
+	} else {
+		buf.WriteString(defaultRenderer.mustExecute(dailyItemsTemplate, page.PRs))
+	}
+
+	return buf.String()
+}
+
+// WriteCoursesIndex 更新课程动态索引文件的标题、日期、描述。
+func WriteCoursesIndex(path string, now time.Time) error {
+	fm := struct {
+		Title       string `yaml:"title"`
+		Date        string `yaml:"date"`
+		Description string `yaml:"description"`
+	}{
+		Title:       "课程动态",
+		Date:        now.Format("2006-01-02"),
+		Description: fmt.Sprintf("各课程仓库的更新日志，最近更新于 %s。", now.Format("2006-01-02")),
+	}
+	out, err := yaml.Marshal(&fm)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	content := fmt.Sprintf("---\n%s---\n", string(out))
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// courseDisplayName 返回课程名称，readme.toml 中缺失时回退为仓库名。
+func courseDisplayName(page CoursePage) string {
+	if name := page.Meta.Name; name != "" {
+		return name
+	}
+	return page.Repo
+}
+
+// groupItemsByRepo 将 Issues 或 Pull Requests 按仓库名分组。
+func groupItemsByRepo(items []github.Item) map[string][]github.Item {
+	grouped := make(map[string][]github.Item)
+	for _, item := range items {
+		grouped[item.Repository.Name] = append(grouped[item.Repository.Name], item)
+	}
+	return grouped
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+)
+
+func TestBuildCourseBody_GroupsByMonth(t *testing.T) {
+	page := CoursePage{
+		Repo: "EE3001",
+		Meta: CourseMeta{
+			Name:     "电路原理",
+			Code:     "EE3001",
+			Credits:  3.5,
+			Teachers: []Teacher{{Name: "张老师"}, {Name: "李老师"}},
+		},
+		Commits: []CommitEntry{
+			{AuthorName: "张三", Date: time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC), Message: "上传试卷", RepoName: "EE3001"},
+			{AuthorName: "李四", Date: time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC), Message: "添加课件\n\n补充说明", RepoName: "EE3001"},
+			{AuthorName: "王五", Date: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC), Message: "修复错误", RepoName: "EE3001"},
+		},
+	}
+
+	body := buildCourseBody("HITSZ-OpenAuto", page)
+
+	for _, want := range []string{
+		"- **课程名称**: 电路原理",
+		"- **课程代码**: EE3001",
+		"- **学分**: 3.5",
+		"- **授课教师**: 张老师、李老师",
+		"- **仓库**: [HITSZ-OpenAuto/EE3001](https://github.com/HITSZ-OpenAuto/EE3001)",
+		"- 2.3 李四：添加课件\n",
+		"暂无待解决的 Issues",
+		"暂无待合并的 Pull Requests",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("buildCourseBody() missing %q\nGot:\n%s", want, body)
+		}
+	}
+
+	iFeb := strings.Index(body, "### 2026 年 2 月")
+	iLisi := strings.Index(body, "李四")
+	iWangwu := strings.Index(body, "王五")
+	iJan := strings.Index(body, "### 2026 年 1 月")
+	iZhangsan := strings.Index(body, "张三")
+	if !(iFeb >= 0 && iFeb < iLisi && iLisi < iWangwu && iWangwu < iJan && iJan < iZhangsan) {
+		t.Fatalf("expected month headings in descending order with commits nested, got:\n%s", body)
+	}
+}
+
+func TestBuildCourseBody_FallbackAndItems(t *testing.T) {
+	page := CoursePage{
+		Repo: "COMP3052",
+		Issues: []github.Item{{
+			Title:      "<b>缺少答案</b>",
+			URL:        "https://github.com/HITSZ-OpenAuto/COMP3052/issues/1",
+			Repository: github.Repository{Name: "COMP3052"},
+			CreatedAt:  "2026-02-13T10:00:00Z",
+		}},
+	}
+
+	body := buildCourseBody("HITSZ-OpenAuto", page)
+
+	if !strings.Contains(body, "- **课程名称**: COMP3052") {
+		t.Errorf("expected repo name fallback, got:\n%s", body)
+	}
+	if !strings.Contains(body, "## 更新日志\n\n暂无更新") {
+		t.Errorf("expected empty changelog placeholder, got:\n%s", body)
+	}
+	if strings.Contains(body, "<b>") {
+		t.Errorf("expected issue title to be escaped, got:\n%s", body)
+	}
+	if !strings.Contains(body, "### [&lt;b&gt;缺少答案&lt;/b&gt;](https://github.com/HITSZ-OpenAuto/COMP3052/issues/1)") {
+		t.Errorf("expected issue link, got:\n%s", body)
+	}
+}
+
+func TestUpdateCoursePage_SkipsUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "EE3001", "index.md")
+	page := CoursePage{
+		Repo: "EE3001",
+		Meta: CourseMeta{Name: "电路原理"},
+		Commits: []CommitEntry{
+			{AuthorName: "张三", Date: time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC), Message: "上传试卷", RepoName: "EE3001"},
+		},
+	}
+
+	changed, err := UpdateCoursePage(path, "HITSZ-OpenAuto", page)
+	if err != nil {
+		t.Fatalf("UpdateCoursePage() returned error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected first write to report a change")
+	}
+
+	// 修改 front matter，模拟日期变化；主体不变时不应重写
+	stale := "---\ntitle: old\ndate: \"2000-01-01\"\n---\n\n" + buildCourseBody("HITSZ-OpenAuto", page)
+	if err := os.WriteFile(path, []byte(stale), 0o644); err != nil {
+		t.Fatalf("failed to seed course page: %v", err)
+	}
+	changed, err = UpdateCoursePage(path, "HITSZ-OpenAuto", page)
+	if err != nil {
+		t.Fatalf("UpdateCoursePage() returned error: %v", err)
+	}
+	if changed {
+		t.Fatalf("expected unchanged body to skip rewrite")
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != stale {
+		t.Fatalf("expected file to be left untouched")
+	}
+
+	page.Commits = append(page.Commits, CommitEntry{AuthorName: "李四", Date: time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC), Message: "添加课件", RepoName: "EE3001"})
+	changed, err = UpdateCoursePage(path, "HITSZ-OpenAuto", page)
+	if err != nil {
+		t.Fatalf("UpdateCoursePage() returned error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected new commit to trigger rewrite")
+	}
+}
+
+func TestParseCourseMeta(t *testing.T) {
+	text := `course_name = '电路原理' # 单引号和行内注释
+repo_name = "EE3001"
+category = "专业核心"
+credit = 4
+semester = """
+秋季"""
+
+[[teachers]]
+name = "张老师"
+
+[[lecturers]]
+name = "李老师"
+`
+	meta, err := ParseCourseMeta(text)
+	if err != nil {
+		t.Fatalf("ParseCourseMeta() returned error: %v", err)
+	}
+	if meta.Name != "电路原理" || meta.Code != "EE3001" || meta.Category != "专业核心" || meta.Credits != 4 || meta.Semester != "秋季" {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+	if got := strings.Join(meta.TeacherNames(), ","); got != "张老师,李老师" {
+		t.Errorf("TeacherNames() = %q, want teachers and lecturers merged", got)
+	}
+}
+
+func TestParseCourseMeta_Errors(t *testing.T) {
+	if _, err := ParseCourseMeta("course_name = \"电路原理\"\ncredit = \n"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected syntax error with line number, got %v", err)
+	}
+
+	meta, err := ParseCourseMeta("course_name = \"电路原理\"\ncredit = -1\n[[teachers]]\nname = \"\"\n")
+	if err == nil || !strings.Contains(err.Error(), "credit") || !strings.Contains(err.Error(), "teacher #1") {
+		t.Errorf("expected validation errors for credit and teacher, got %v", err)
+	}
+	if meta.Name != "电路原理" {
+		t.Errorf("expected parsed meta returned alongside validation error, got %+v", meta)
+	}
+
+	if _, err := ParseCourseMeta("repo_name = \"EE3001\"\n"); err == nil {
+		t.Errorf("expected error for missing course_name")
+	}
+}
@@ -9,10 +9,11 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
 )
 
@@ -20,7 +21,7 @@ import (
 
 const newsGoroutineLimit = 80 // 并发限制，避免过多协程触发 GitHub 限流
 
-func Daily(orgName string, publicRepos map[string]struct{}) error {
+func Daily(orgName string, publicRepos map[string]struct{}, cfg *config.Config) error {
 	issues, err := github.SearchIssues(orgName, 150)
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
@@ -39,113 +40,120 @@ func Daily(orgName string, publicRepos map[string]struct{}) error {
 	issues = filterBracketedIssues(issues)
 	log.Printf("Filtered bracketed issues, issues=%d", len(issues))
 
-	if err := UpdateDailyReport("news/daily.md", orgName, publicRepos, issues, prs); err != nil {
+	if err := UpdateDailyReport(dailyPath, orgName, publicRepos, issues, prs, cfg); err != nil {
 		return fmt.Errorf("failed to update daily report: %w", err)
 	}
 
 	return nil
 }
 
-func UpdateDailyReport(path string, orgName string, publicRepos map[string]struct{}, issues []github.Item, prs []github.Item) error {
+// UpdateDailyReport 生成 cfg.Languages 中每种语言的日报，默认语言写到 path，
+// 其他语言写到带语言后缀的同级文件（如 news/daily.en.md）。JSON 和 JSON Feed 与语言无关，只写一份。
+func UpdateDailyReport(path string, orgName string, publicRepos map[string]struct{}, issues []github.Item, prs []github.Item, cfg *config.Config) error {
+	renderers, err := newRenderers(cfg) // 先加载模板，模板有误时不必拉取数据
+	if err != nil {
+		return err
+	}
 	startTime := time.Now().Add(-24 * time.Hour)
 
-	var (
-		mu        sync.Mutex
-		wg        sync.WaitGroup
-		commits   = make([]CommitEntry, 0)
-		repoNames = make(map[string]string)                 // repo 名 -> 课程名的映射
-		goLimit   = make(chan struct{}, newsGoroutineLimit) // 限制并发数，避免过多请求导致失败
-	)
-
-	for repo := range publicRepos {
-		wg.Add(1)
-		go func(repo string) {
-			defer wg.Done()
-
-			goLimit <- struct{}{}        // 获取限流令牌
-			defer func() { <-goLimit }() // 释放令牌
-
-			repoCommits, err := github.ListCommitsSince(orgName, repo, startTime.Format(time.RFC3339))
-			if err != nil {
-				log.Printf("Failed to fetch commits for %s: %v", repo, err)
-				return
-			}
-
-			localCommits := make([]CommitEntry, 0, len(repoCommits)) // 区分本地和全局，是为了减少锁的粒度，提升性能
-			for _, commit := range repoCommits {
-				authorName := commit.Commit.Author.Name
-				authorLogin := ""
-				if commit.Author != nil {
-					authorLogin = commit.Author.Login
-				}
-				if utils.IsBot(authorName, authorLogin) {
-					continue // 过滤掉 bot 提交，比如 actions 自动生成的就不需要计数
-				}
-				if !utils.IsChineseCommit(commit.Commit.Message) {
-					continue // 只保留中文提交，过滤代码提交等非中文信息
-				}
-
-				date, err := time.Parse(time.RFC3339, commit.Commit.Author.Date)
-				if err != nil {
-					continue
-				}
-				date = date.In(utils.BeijingTimeZone) // Convert to BJT
-				localCommits = append(localCommits, CommitEntry{
-					AuthorName:  authorName,
-					AuthorLogin: authorLogin,
-					Date:        date,
-					Message:     commit.Commit.Message,
-					RepoName:    repo,
-				})
-			}
-
-			if len(localCommits) == 0 {
-				log.Printf("Finished commits process for %s (no valid commits)", repo)
-				return
-			}
-
-			var courseName string
-			if name, err := fetchCourseName(orgName, repo); err == nil && name != "" {
-				courseName = name
-			}
-
-			mu.Lock()
-			commits = append(commits, localCommits...)
-			if courseName != "" {
-				repoNames[repo] = courseName
-			}
-			mu.Unlock()
+	opts := newCollectOptions(cfg, true)
+	commits, courses := collectCommits(orgName, publicRepos, startTime, newsGoroutineLimit, opts)
+	repoNames := courseTitles(courses)
+	automated := 0
+	if cfg.Bots.ShowCount {
+		automated = opts.drops.count(ruleBot, ruleAutomation)
+	}
+	var doc *ReportDocument // 未开启 output.json 时为 nil
+	if cfg.Output.JSON {
+		window := ReportWindow{Start: startTime.UTC().Truncate(time.Second), End: time.Now().UTC().Truncate(time.Second)}
+		d := newReportDocument(ReportKindDaily, orgName, window, commits, courses, issues, prs, automated)
+		doc = &d
+	}
+	if cfg.Daily.FeedDays > 0 {
+		// JSON Feed 按条目累积，与日报正文是否变化无关，每次运行都合并并清理过期条目
+		items := newDailyFeedItems(orgName, commits, repoNames, issues, prs)
+		if err := updateDailyFeed(dailyFeedPath(path), cfg.Feed.SiteURL, items, cfg.Daily.FeedDays, time.Now()); err != nil {
+			return err
+		}
+	}
+	commits = dedupeCommits(commits, cfg.Dedupe.Window)
 
-			log.Printf("Finished commits process for %s", repo)
-		}(repo)
+	var departments map[string]string // 为 nil 时按类别分组
+	if cfg.Daily.Layout == config.LayoutDepartment {
+		departments = courseDepartments(commits, courses, cfg.Departments)
 	}
-	wg.Wait()
 
-	log.Printf("Commit collection complete, %d total valid commits", len(commits))
+	rewritten := false
+	for _, r := range renderers {
+		body, err := buildDailyBody(r, orgName, commits, repoNames, departments, issues, prs, automated)
+		if err != nil {
+			return err
+		}
+		changed, err := writeDailyPage(r.lang.Path(path), r.lang, body, len(commits) == 0)
+		if err != nil {
+			return err
+		}
+		rewritten = rewritten || changed
+	}
+	if doc == nil {
+		return nil
+	}
+	// JSON 与 Markdown 同步更新，页面均未变化时只在刚开启 output.json、文件还不存在时补写
+	old, err := readReportJSON(reportJSONPath(path))
+	if err != nil {
+		log.Printf("Failed to read existing daily JSON: %v", err)
+	}
+	if !rewritten && (old != nil || err != nil) {
+		return nil
+	}
+	if len(commits) == 0 && old != nil && old.Version == ReportSchemaVersion {
+		doc.keepCommits(*old) // 页面保留了上一次的提交，JSON 同样保留
+	}
+	return writeReportJSON(reportJSONPath(path), *doc)
+}
 
-	body := buildDailyBody(orgName, commits, repoNames, issues, prs)
+// newRenderers 为 cfg.Languages 中的每种语言加载模板，未配置语言时只输出默认语言。
+func newRenderers(cfg *config.Config) ([]*renderer, error) {
+	langs := cfg.Languages
+	if len(langs) == 0 {
+		langs = []i18n.Lang{i18n.Default}
+	}
+	renderers := make([]*renderer, 0, len(langs))
+	for _, lang := range langs {
+		r, err := newRenderer(cfg.TemplatesDir, lang)
+		if err != nil {
+			return nil, err
+		}
+		renderers = append(renderers, r)
+	}
+	return renderers, nil
+}
 
+// writeDailyPage 将 lang 语言的日报正文连同 front matter 写入 path，正文与已有文件实质相同时不改写。
+// empty 表示时间窗口内没有提交，此时保留已有文件中的「最近更新」段落。返回文件是否被改写。
+func writeDailyPage(path string, lang i18n.Lang, body string, empty bool) (bool, error) {
 	oldContent, readErr := os.ReadFile(path)
 	if readErr == nil {
 		// If no commits today, preserve old commits section instead of showing 暂无更新
-		if len(commits) == 0 {
+		if empty {
+			header := "## " + lang.T("daily.recent")
 			oldBody := extractBody(string(oldContent))
-			if oldSection := extractSection(oldBody, "## 最近更新"); oldSection != "" {
-				body = strings.Replace(body, "## 最近更新\n\n暂无更新\n\n", "## 最近更新\n\n"+oldSection, 1)
+			if oldSection := extractSection(oldBody, header); oldSection != "" {
+				body = strings.Replace(body, header+"\n\n"+lang.T("daily.empty")+"\n\n", header+"\n\n"+oldSection, 1)
 			}
 		}
 		if isSubstantivelyEqual(string(oldContent), body) {
 			log.Printf("Daily report body unchanged, skip rewriting %s", path)
-			return nil
+			return false, nil
 		}
 	} else if !errors.Is(readErr, fs.ErrNotExist) {
-		return fmt.Errorf("failed to read existing daily report %q: %w", path, readErr)
+		return false, fmt.Errorf("failed to read existing daily report %q: %w", path, readErr)
 	}
 
 	fm, err := utils.GenerateFrontMatter(
-		"AUTO 更新速递",
+		lang.T("daily.title"),
 		time.Now().UTC().Format("2006-01-02"),
-		"每日更新",
+		lang.T("daily.description"),
 		[]utils.Author{{
 			Name:  "github-actions[bot]",
 			Link:  "https://github.com/features/actions",
@@ -153,7 +161,7 @@ func UpdateDailyReport(path string, orgName string, publicRepos map[string]struc
 		}},
 	)
 	if err != nil {
-		return fmt.Errorf("failed to generate front matter: %w", err)
+		return false, fmt.Errorf("failed to generate front matter: %w", err)
 	}
 
 	var final strings.Builder
@@ -162,80 +170,56 @@ func UpdateDailyReport(path string, orgName string, publicRepos map[string]struc
 	final.WriteString("---\n\n")
 	final.WriteString(body)
 
-	return os.WriteFile(path, []byte(final.String()), 0o644)
+	if err := os.WriteFile(path, []byte(final.String()), 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-func buildDailyBody(orgName string, commits []CommitEntry, repoNames map[string]string, issues []github.Item, prs []github.Item) string {
+// buildDailyBody 使用 r 中的模板渲染日报主体。departments 非 nil 时先按院系分组，再按类别分组；
+// automated 为未列出的自动化操作提交数，大于 0 时在更新列表末尾注明。
+func buildDailyBody(r *renderer, orgName string, commits []CommitEntry, repoNames, departments map[string]string, issues []github.Item, prs []github.Item, automated int) (string, error) {
 	// 按照确定的规则进行排序，确保对于相同的更新内容，生成的报告内容顺序一致
 	// 便于后续比对前后的内容
 	sortCommits(commits)
 	sortItems(issues)
 	sortItems(prs)
 
-	var buf strings.Builder
-
-	// Commits
-	buf.WriteString("## 最近更新\n\n")
-	if len(commits) == 0 {
-		buf.WriteString("暂无更新\n\n")
-	} else {
-		for _, commit := range commits {
-			author := utils.SanitizeInlineText(commit.AuthorName)
-			repoName := repoNames[commit.RepoName]
-			if repoName == "" {
-				repoName = commit.RepoName
-			}
-			repoName = utils.SanitizeInlineText(repoName)
-			message := utils.SanitizeInlineText(strings.Split(commit.Message, "\n")[0])
-			fmt.Fprintf(&buf,
-				"- %s 在 [%s](https://github.com/%s/%s) 中提交了信息：%s (%s)\n\n",
-				author, repoName, orgName, commit.RepoName, message, commit.Date.Format("15:04"))
-		}
+	data := DailyData{
+		Org:       orgName,
+		Empty:     len(commits) == 0,
+		Automated: automated,
+		Issues:    issues,
+		PRs:       prs,
 	}
-
-	// Issues
-	buf.WriteString("## 待解决的 Issues\n\n")
-	if len(issues) == 0 {
-		buf.WriteString("暂无待解决的 Issues\n\n")
-	} else {
-		for _, issue := range issues {
-			fmt.Fprintf(&buf, "### %s\n\n", utils.RenderSafeMarkdownLink(issue.Title, issue.URL))
-			fmt.Fprintf(&buf, "- **仓库**: %s\n", utils.SanitizeInlineText(issue.Repository.Name))
-			fmt.Fprintf(&buf, "- **创建于**: %s\n", utils.UTCToBJT(issue.CreatedAt))
-			fmt.Fprintf(&buf, "- **作者**: %s\n", utils.SanitizeInlineText(issue.Author.Login))
-			if len(issue.Labels) > 0 {
-				labels := make([]string, 0, len(issue.Labels))
-				for _, label := range issue.Labels {
-					labels = append(labels, utils.SanitizeInlineText(label.Name))
-				}
-				fmt.Fprintf(&buf, "- **标签**: %s\n", strings.Join(labels, ", "))
-			}
-			buf.WriteString("\n")
+	if departments != nil {
+		groups, batches := groupByDepartment(commits, departments, repoNames)
+		for _, group := range groups {
+			data.Departments = append(data.Departments, DepartmentView{
+				Title:      departmentTitle(group.Department),
+				Count:      group.Count,
+				Categories: newCategoryViews(r.lang, "####", group.Commits, repoNames, orgName),
+			})
 		}
+		data.Batches = newCategoryViews(r.lang, "####", batches, repoNames, orgName)
+	} else {
+		data.Categories = newCategoryViews(r.lang, "###", commits, repoNames, orgName)
 	}
+	return r.execute(dailyTemplate, data)
+}
 
-	// Pull Requests
-	buf.WriteString("## 待合并的 Pull Requests\n\n")
-	if len(prs) == 0 {
-		buf.WriteString("暂无待合并的 Pull Requests\n\n")
-	} else {
-		for _, pr := range prs {
-			fmt.Fprintf(&buf, "### %s\n\n", utils.RenderSafeMarkdownLink(pr.Title, pr.URL))
-			fmt.Fprintf(&buf, "- **仓库**: %s\n", utils.SanitizeInlineText(pr.Repository.Name))
-			fmt.Fprintf(&buf, "- **创建于**: %s\n", utils.UTCToBJT(pr.CreatedAt))
-			fmt.Fprintf(&buf, "- **作者**: %s\n", utils.SanitizeInlineText(pr.Author.Login))
-			if len(pr.Labels) > 0 {
-				labels := make([]string, 0, len(pr.Labels))
-				for _, label := range pr.Labels {
-					labels = append(labels, utils.SanitizeInlineText(label.Name))
-				}
-				fmt.Fprintf(&buf, "- **标签**: %s\n", strings.Join(labels, ", "))
-			}
-			buf.WriteString("\n")
+// renderAuthor 渲染提交作者名及共同作者，存在 GitHub 登录名时链接到其个人主页。
+func renderAuthor(lang i18n.Lang, commit CommitEntry) string {
+	credits := commitCredits(commit)
+	names := make([]string, len(credits))
+	for i, id := range credits {
+		if id.Login == "" {
+			names[i] = utils.SanitizeInlineText(id.Name)
+		} else {
+			names[i] = utils.RenderSafeMarkdownLink(id.Name, id.ProfileURL())
 		}
 	}
-
-	return buf.String()
+	return lang.List(names)
 }
 
 // 排序优先级：按照时间（从新到旧）、仓库名、提交信息、作者名、作者登录名排序
@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
 )
 
@@ -59,7 +61,7 @@ func TestUpdateDailyReport_EmptyData(t *testing.T) {
 	var issues []github.Item
 	var prs []github.Item
 
-	err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs)
+	err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs, &config.Config{})
 	if err != nil {
 		t.Fatalf("UpdateDailyReport() returned error: %v", err)
 	}
@@ -106,7 +108,7 @@ func TestUpdateDailyReport_WithData(t *testing.T) {
 		},
 	}
 
-	err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs)
+	err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs, &config.Config{})
 	if err != nil {
 		t.Fatalf("UpdateDailyReport() returned error: %v", err)
 	}
@@ -159,7 +161,7 @@ func TestUpdateDailyReport_EscapeMDXPayloadInTitles(t *testing.T) {
 		},
 	}
 
-	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs); err != nil {
+	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs, &config.Config{}); err != nil {
 		t.Fatalf("UpdateDailyReport() returned error: %v", err)
 	}
 
@@ -225,7 +227,7 @@ func TestUpdateDailyReport_UnchangedBodySkipsRewrite(t *testing.T) {
 	var issues []github.Item
 	var prs []github.Item
 
-	existingBody := buildDailyBody(orgName, nil, map[string]string{}, nil, nil)
+	existingBody := renderDailyBody(t, orgName, nil, map[string]string{}, nil, nil, nil, 0)
 	// The old file intentionally uses CRLF + trailing spaces; normalizeBody should still match.
 	existingBody = strings.ReplaceAll(existingBody, "\n", "  \r\n")
 	existing := "---\n" +
@@ -237,7 +239,7 @@ func TestUpdateDailyReport_UnchangedBodySkipsRewrite(t *testing.T) {
 		t.Fatalf("failed to seed existing daily report: %v", err)
 	}
 
-	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs); err != nil {
+	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs, &config.Config{}); err != nil {
 		t.Fatalf("UpdateDailyReport() returned error: %v", err)
 	}
 
@@ -318,7 +320,7 @@ func TestUpdateDailyReport_SortsIssuesAndPRsDeterministically(t *testing.T) {
 		},
 	}
 
-	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs); err != nil {
+	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs, &config.Config{}); err != nil {
 		t.Fatalf("UpdateDailyReport() returned error: %v", err)
 	}
 
@@ -429,7 +431,7 @@ func TestUpdateDailyReport_BodyChangedRewritesFile(t *testing.T) {
 		"title: AUTO 更新速递\n" +
 		"date: \"1999-01-01\"\n" +
 		"---\n\n" +
-		buildDailyBody(orgName, nil, map[string]string{}, nil, nil)
+		renderDailyBody(t, orgName, nil, map[string]string{}, nil, nil, nil, 0)
 	if err := os.WriteFile(tmpFile, []byte(existing), 0o644); err != nil {
 		t.Fatalf("failed to seed existing daily report: %v", err)
 	}
@@ -449,7 +451,7 @@ func TestUpdateDailyReport_BodyChangedRewritesFile(t *testing.T) {
 	}
 
 	expectedDate := time.Now().UTC().Format("2006-01-02")
-	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs); err != nil {
+	if err := UpdateDailyReport(tmpFile, orgName, publicRepos, issues, prs, &config.Config{}); err != nil {
 		t.Fatalf("UpdateDailyReport() returned error: %v", err)
 	}
 
@@ -473,6 +475,45 @@ func TestUpdateDailyReport_BodyChangedRewritesFile(t *testing.T) {
 	}
 }
 
+func TestUpdateDailyReport_EmptyWindowKeepsJSONCommits(t *testing.T) {
+	tmpFile := t.TempDir() + "/daily.md"
+	orgName := "test-org"
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 12, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "EE3001", SHA: "abc"},
+	}
+	existing := "---\ntitle: AUTO 更新速递\ndate: \"2026-02-12\"\n---\n\n" + renderDailyBody(t, orgName, commits, nil, nil, nil, nil, 0)
+	if err := os.WriteFile(tmpFile, []byte(existing), 0o644); err != nil {
+		t.Fatalf("failed to seed existing daily report: %v", err)
+	}
+	window := ReportWindow{Start: time.Date(2026, 2, 11, 10, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 12, 10, 0, 0, 0, time.UTC)}
+	if err := writeReportJSON(reportJSONPath(tmpFile), newReportDocument(ReportKindDaily, orgName, window, commits, nil, nil, nil, 2)); err != nil {
+		t.Fatalf("failed to seed existing daily JSON: %v", err)
+	}
+
+	issues := []github.Item{{
+		Title: "new issue", URL: "https://example.com/issues/1", CreatedAt: "2026-02-13T10:00:00Z",
+		Repository: github.Repository{Name: "EE3001"}, Author: github.Author{Login: "u1"},
+	}}
+	if err := UpdateDailyReport(tmpFile, orgName, map[string]struct{}{}, issues, nil, &config.Config{Output: config.OutputConfig{JSON: true}}); err != nil {
+		t.Fatalf("UpdateDailyReport() returned error: %v", err)
+	}
+
+	page, err := os.ReadFile(tmpFile)
+	if err != nil || !strings.Contains(string(page), "上传期末试卷") || !strings.Contains(string(page), "new issue") {
+		t.Fatalf("expected page to keep old commits and list the new issue, got:\n%s (%v)", page, err)
+	}
+	doc, err := readReportJSON(reportJSONPath(tmpFile))
+	if err != nil || doc == nil {
+		t.Fatalf("readReportJSON() = %v, %v", doc, err)
+	}
+	if len(doc.Commits) != 1 || doc.Commits[0].SHA != "abc" || doc.Automated != 2 || !doc.Window.Start.Equal(window.Start) {
+		t.Errorf("JSON should keep the commits shown on the page, got commits %+v, automated %d, window %+v", doc.Commits, doc.Automated, doc.Window)
+	}
+	if len(doc.Issues) != 1 || doc.Issues[0].Title != "new issue" {
+		t.Errorf("JSON should list the current issues, got %+v", doc.Issues)
+	}
+}
+
 func TestUpdateDailyReport_ReadFileError(t *testing.T) {
 	// Passing a directory path triggers a non-IsNotExist read error.
 	path := t.TempDir()
@@ -481,7 +522,7 @@ func TestUpdateDailyReport_ReadFileError(t *testing.T) {
 	var issues []github.Item
 	var prs []github.Item
 
-	err := UpdateDailyReport(path, orgName, publicRepos, issues, prs)
+	err := UpdateDailyReport(path, orgName, publicRepos, issues, prs, &config.Config{})
 	if err == nil {
 		t.Fatalf("expected read error when path is a directory")
 	}
@@ -537,19 +578,23 @@ func TestBuildDailyBody_DeterministicAcrossRuns(t *testing.T) {
 		},
 	}
 
-	body1 := buildDailyBody(
+	body1 := renderDailyBody(t,
 		orgName,
 		cloneCommits(commits),
 		map[string]string{},
+		nil,
 		cloneItems(issues),
 		cloneItems(prs),
+		0,
 	)
-	body2 := buildDailyBody(
+	body2 := renderDailyBody(t,
 		orgName,
 		cloneCommits(commits),
 		map[string]string{},
+		nil,
 		cloneItems(issues),
 		cloneItems(prs),
+		0,
 	)
 	if body1 != body2 {
 		t.Fatalf("buildDailyBody output is not deterministic across runs")
@@ -566,7 +611,7 @@ func TestBuildDailyBody_SortsCommitsWithTieBreakers(t *testing.T) {
 		{AuthorName: "alice", Date: date, Message: "m1", RepoName: "repo-a"},
 	}
 
-	body := buildDailyBody(orgName, commits, map[string]string{}, nil, nil)
+	body := renderDailyBody(t, orgName, commits, map[string]string{}, nil, nil, nil, 0)
 
 	iAlice := strings.Index(body, "alice 在 [repo-a](https://github.com/test-org/repo-a) 中提交了信息：m1")
 	iZoe := strings.Index(body, "zoe 在 [repo-a](https://github.com/test-org/repo-a) 中提交了信息：m1")
@@ -596,3 +641,57 @@ func cloneCommits(commits []CommitEntry) []CommitEntry {
 	copy(out, commits)
 	return out
 }
+
+// renderDailyBody 使用内置模板渲染日报主体。
+func renderDailyBody(t *testing.T, orgName string, commits []CommitEntry, repoNames, departments map[string]string, issues, prs []github.Item, automated int) string {
+	t.Helper()
+	body, err := buildDailyBody(defaultRenderer, orgName, commits, repoNames, departments, issues, prs, automated)
+	if err != nil {
+		t.Fatalf("buildDailyBody() returned error: %v", err)
+	}
+	return body
+}
+
+func TestUpdateDailyReport_Languages(t *testing.T) {
+	dir := t.TempDir()
+	issues := []github.Item{{
+		Title: "补充实验报告", URL: "https://github.com/test-org/repo/issues/1",
+		Repository: github.Repository{Name: "test-repo"}, Author: github.Author{Login: "testuser"}, CreatedAt: "2026-02-13T10:00:00Z",
+	}}
+	cfg := &config.Config{Languages: []i18n.Lang{i18n.ZH, i18n.EN}}
+	if err := UpdateDailyReport(dir+"/daily.md", "test-org", nil, issues, nil, cfg); err != nil {
+		t.Fatalf("UpdateDailyReport() returned error: %v", err)
+	}
+
+	zh, err := os.ReadFile(dir + "/daily.md")
+	if err != nil {
+		t.Fatalf("failed to read Chinese report: %v", err)
+	}
+	en, err := os.ReadFile(dir + "/daily.en.md")
+	if err != nil {
+		t.Fatalf("failed to read English report: %v", err)
+	}
+	for _, want := range []string{"title: AUTO 更新速递", "## 最近更新\n\n暂无更新", "- **仓库**: test-repo", "暂无待合并的 Pull Requests"} {
+		if !strings.Contains(string(zh), want) {
+			t.Errorf("Chinese report missing %q:\n%s", want, zh)
+		}
+	}
+	for _, want := range []string{"title: AUTO Daily Updates", "## Recent Updates\n\nNo updates", "- **Repository**: test-repo", "No open pull requests"} {
+		if !strings.Contains(string(en), want) {
+			t.Errorf("English report missing %q:\n%s", want, en)
+		}
+	}
+
+	// 两种语言的 front matter 除标题和描述外保持一致
+	zhFM, _, _, err := splitFrontMatter(string(zh))
+	if err != nil {
+		t.Fatalf("failed to parse front matter: %v", err)
+	}
+	enFM, _, _, err := splitFrontMatter(string(en))
+	if err != nil {
+		t.Fatalf("failed to parse front matter: %v", err)
+	}
+	if zhFM.Date != enFM.Date || len(enFM.Authors) != 1 || enFM.Authors[0].Name != zhFM.Authors[0].Name {
+		t.Errorf("front matter mismatch: zh=%+v en=%+v", zhFM, enFM)
+	}
+}
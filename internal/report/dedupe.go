@@ -0,0 +1,119 @@
+// 批量操作去重：将同一作者在短时间内对多个仓库提交的相同信息合并为一条
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+const batchInlineCourses = 3 // 合并条目中直接列出的课程数，超出部分折叠到 <details> 中
+
+var trailingPRRef = regexp.MustCompile(`\s*\(#\d+\)$`)
+
+// dedupeCommits 将作者相同、规范化后的提交信息相同、且相邻提交间隔不超过 window 的 commit 聚为一组，
+// 每组以最新的一条作为代表，其余提交记录在代表的 Batch 字段中。window 不大于 0 时不做去重。
+// 返回结果无序，且不修改传入的切片。
+func dedupeCommits(commits []CommitEntry, window time.Duration) []CommitEntry {
+	if window <= 0 || len(commits) < 2 {
+		return commits
+	}
+	sorted := make([]CommitEntry, len(commits))
+	copy(sorted, commits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	clusters := make([][]CommitEntry, 0, len(sorted))
+	open := make(map[string]int) // 去重键 -> 最近一个簇在 clusters 中的下标
+	for _, commit := range sorted {
+		key := contributorKey(commit) + "\x00" + normalizeMessage(commit.Message)
+		if i, ok := open[key]; ok {
+			last := clusters[i][len(clusters[i])-1]
+			if commit.Date.Sub(last.Date) <= window {
+				clusters[i] = append(clusters[i], commit)
+				continue
+			}
+		}
+		open[key] = len(clusters)
+		clusters = append(clusters, []CommitEntry{commit})
+	}
+
+	deduped := make([]CommitEntry, 0, len(clusters))
+	for _, cluster := range clusters {
+		representative := cluster[len(cluster)-1]
+		if len(cluster) > 1 {
+			representative.Batch = cluster[:len(cluster)-1]
+		}
+		deduped = append(deduped, representative)
+	}
+	return deduped
+}
+
+// normalizeMessage 返回用于去重比较的提交信息：只取首行，去掉末尾的 PR 编号，合并空白并转为小写。
+func normalizeMessage(message string) string {
+	subject := strings.TrimSpace(strings.Split(message, "\n")[0])
+	subject = trailingPRRef.ReplaceAllString(subject, "")
+	return strings.ToLower(strings.Join(strings.Fields(subject), " "))
+}
+
+// batchSize 返回 commit 代表的提交数，包括合并进来的同批次提交。
+func batchSize(commit CommitEntry) int {
+	return 1 + len(commit.Batch)
+}
+
+// batchSuffix 返回附在提交信息后的批量提交次数，单条提交时返回空字符串。
+func batchSuffix(lang i18n.Lang, commit CommitEntry) string {
+	if n := batchSize(commit); n > 1 {
+		return lang.T("commit.batch", n)
+	}
+	return ""
+}
+
+// batchRepos 返回 commit 及其同批次提交涉及的仓库，按课程名排序。
+func batchRepos(commit CommitEntry, repoTitles map[string]string) []string {
+	seen := map[string]struct{}{commit.RepoName: {}}
+	repos := []string{commit.RepoName}
+	for _, c := range commit.Batch {
+		if _, ok := seen[c.RepoName]; ok {
+			continue
+		}
+		seen[c.RepoName] = struct{}{}
+		repos = append(repos, c.RepoName)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		return courseTitle(repos[i], repoTitles) < courseTitle(repos[j], repoTitles)
+	})
+	return repos
+}
+
+// renderCommitLocation 渲染提交所在的课程。单个仓库时返回课程链接；
+// 批量提交时返回前几门课程的链接和课程总数，课程过多时额外返回列出全部课程的 <details> 块。
+func renderCommitLocation(lang i18n.Lang, commit CommitEntry, repoTitles map[string]string, orgName string) (location, details string) {
+	repos := batchRepos(commit, repoTitles)
+	links := make([]string, len(repos))
+	for i, repo := range repos {
+		links[i] = utils.RenderSafeMarkdownLink(courseTitle(repo, repoTitles), fmt.Sprintf("https://github.com/%s/%s", orgName, repo))
+	}
+
+	if len(repos) == 1 {
+		return links[0], ""
+	}
+	if len(repos) <= batchInlineCourses {
+		return lang.T("location.all", lang.List(links), len(repos)), ""
+	}
+
+	location = lang.T("location.more", lang.List(links[:batchInlineCourses]), len(repos))
+	var b strings.Builder
+	fmt.Fprintf(&b, "<details>\n<summary>%s</summary>\n\n", lang.T("location.details", len(repos)))
+	for _, link := range links {
+		fmt.Fprintf(&b, "- %s\n", link)
+	}
+	b.WriteString("\n</details>\n\n")
+	return location, b.String()
+}
@@ -0,0 +1,85 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+)
+
+func TestDedupeCommits(t *testing.T) {
+	base := time.Date(2026, 2, 13, 10, 0, 0, 0, time.UTC)
+	commits := []CommitEntry{
+		{AuthorLogin: "admin", Date: base, Message: "更新 readme.toml", RepoName: "A"},
+		{AuthorLogin: "admin", Date: base.Add(time.Minute), Message: "更新 readme.toml (#3)", RepoName: "B"},
+		{AuthorLogin: "admin", Date: base.Add(2 * time.Minute), Message: "更新  README.toml\n\n补充说明", RepoName: "C"},
+		{AuthorLogin: "admin", Date: base.Add(3 * time.Hour), Message: "更新 readme.toml", RepoName: "D"}, // 超出时间窗口
+		{AuthorLogin: "other", Date: base.Add(time.Minute), Message: "更新 readme.toml", RepoName: "E"},   // 作者不同
+	}
+
+	deduped := dedupeCommits(commits, 30*time.Minute)
+	if len(deduped) != 3 {
+		t.Fatalf("dedupeCommits() returned %d entries, want 3: %+v", len(deduped), deduped)
+	}
+	var batch CommitEntry
+	for _, commit := range deduped {
+		if len(commit.Batch) > 0 {
+			batch = commit
+		}
+	}
+	if batch.RepoName != "C" || batchSize(batch) != 3 {
+		t.Errorf("batch representative = %s with %d commits, want C with 3", batch.RepoName, batchSize(batch))
+	}
+	if got := strings.Join(batchRepos(batch, nil), ","); got != "A,B,C" {
+		t.Errorf("batchRepos() = %s, want A,B,C", got)
+	}
+
+	if got := dedupeCommits(commits, 0); len(got) != len(commits) {
+		t.Errorf("dedupeCommits() with zero window should keep all commits, got %d", len(got))
+	}
+}
+
+func TestRenderCommitLocation(t *testing.T) {
+	commit := CommitEntry{RepoName: "A"}
+	for _, repo := range []string{"B", "C", "D", "E"} {
+		commit.Batch = append(commit.Batch, CommitEntry{RepoName: repo})
+	}
+	titles := map[string]string{"A": "课程A"}
+
+	location, details := renderCommitLocation(i18n.Default, commit, titles, "HITSZ-OpenAuto")
+	if want := "[B](https://github.com/HITSZ-OpenAuto/B)、[C](https://github.com/HITSZ-OpenAuto/C)、[D](https://github.com/HITSZ-OpenAuto/D) 等 5 门课程"; location != want {
+		t.Errorf("location = %q, want %q", location, want)
+	}
+	for _, want := range []string{"<details>", "查看全部 5 门课程", "- [课程A](https://github.com/HITSZ-OpenAuto/A)", "</details>"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details missing %q, got:\n%s", want, details)
+		}
+	}
+
+	location, details = renderCommitLocation(i18n.Default, CommitEntry{RepoName: "A"}, titles, "HITSZ-OpenAuto")
+	if location != "[课程A](https://github.com/HITSZ-OpenAuto/A)" || details != "" {
+		t.Errorf("single commit location = %q, details = %q", location, details)
+	}
+
+	location, _ = renderCommitLocation(i18n.Default, CommitEntry{RepoName: "A"}, map[string]string{"A": "信号与系统 [实验](旧)"}, "HITSZ-OpenAuto")
+	if want := `[信号与系统 \[实验\]\(旧\)](https://github.com/HITSZ-OpenAuto/A)`; location != want {
+		t.Errorf("location with brackets = %q, want %q", location, want)
+	}
+}
+
+func TestBuildMarkdown_RendersBatchOnce(t *testing.T) {
+	base := time.Date(2026, 2, 13, 10, 0, 0, 0, time.UTC)
+	commits := make([]CommitEntry, 0)
+	for i, repo := range []string{"A", "B", "C", "D"} {
+		commits = append(commits, CommitEntry{AuthorName: "管理员", Date: base.Add(time.Duration(i) * time.Minute), Message: "同步课程模板", RepoName: repo})
+	}
+
+	result := BuildMarkdown(dedupeCommits(commits, 30*time.Minute), nil, "HITSZ-OpenAuto")
+	if n := strings.Count(result, "同步课程模板"); n != 1 {
+		t.Errorf("batch should be rendered once, got %d times:\n%s", n, result)
+	}
+	if !strings.Contains(result, "等 4 门课程") || !strings.Contains(result, "（共 4 次提交）") {
+		t.Errorf("batch entry should list courses and count, got:\n%s", result)
+	}
+}
@@ -0,0 +1,108 @@
+// 按院系分组：院系来自 readme.toml，缺失时根据课程代码前缀推断
+package report
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+// codePrefix 返回课程代码开头的字母部分，如 EE3001 -> EE、Cross-EIE -> Cross。
+func codePrefix(code string) string {
+	end := strings.IndexFunc(code, func(r rune) bool {
+		return !unicode.IsLetter(r) || r > unicode.MaxASCII
+	})
+	if end < 0 {
+		return code
+	}
+	return code[:end]
+}
+
+// departmentOf 返回课程所属的院系名：优先使用元数据中的 department，
+// 其次按课程代码（缺失时为仓库名）的前缀查表（cfg.Prefixes 的键已由 config.Load 统一为大写），
+// 都无法判定时返回 cfg.Fallback。
+func departmentOf(repo string, meta CourseMeta, cfg config.DepartmentsConfig) string {
+	if department := strings.TrimSpace(meta.Department); department != "" {
+		return department
+	}
+	prefixes := cfg.Prefixes
+	if prefixes == nil {
+		prefixes = config.DefaultDepartmentPrefixes
+	}
+	for _, code := range []string{meta.Code, repo} {
+		prefix := codePrefix(code)
+		if prefix == "" {
+			continue
+		}
+		if name, ok := prefixes[strings.ToUpper(prefix)]; ok {
+			return name
+		}
+	}
+	return cfg.Fallback
+}
+
+// courseDepartments 为 commits 涉及的每个仓库（含批量提交中的仓库）判定院系，返回 repo 名 -> 院系名的映射。
+func courseDepartments(commits []CommitEntry, courses map[string]CourseMeta, cfg config.DepartmentsConfig) map[string]string {
+	departments := make(map[string]string)
+	for _, commit := range commits {
+		for _, repo := range batchRepos(commit, nil) {
+			if _, ok := departments[repo]; !ok {
+				departments[repo] = departmentOf(repo, courses[repo], cfg)
+			}
+		}
+	}
+	return departments
+}
+
+// departmentGroup 是同一院系下的一组 commit。
+type departmentGroup struct {
+	Department string
+	Commits    []CommitEntry
+	Count      int // 含去重合并的提交数
+}
+
+// groupByDepartment 按院系将 commit 分组，组按提交数降序、院系名升序排列，组内保持原有顺序。
+// 涉及多门课程的批量提交不参与分组，单独返回。
+func groupByDepartment(commits []CommitEntry, departments, repoTitles map[string]string) (groups []departmentGroup, batches []CommitEntry) {
+	index := make(map[string]int)
+	for _, commit := range commits {
+		if len(batchRepos(commit, repoTitles)) > 1 {
+			batches = append(batches, commit)
+			continue
+		}
+		department := departments[commit.RepoName]
+		i, ok := index[department]
+		if !ok {
+			i = len(groups)
+			index[department] = i
+			groups = append(groups, departmentGroup{Department: department})
+		}
+		groups[i].Commits = append(groups[i].Commits, commit)
+		groups[i].Count += batchSize(commit)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Department < groups[j].Department
+	})
+	return groups, batches
+}
+
+// departmentTitle 返回院系分组标题，departments 中缺少的仓库归入 config.DefaultDepartmentFallback。
+func departmentTitle(department string) string {
+	if department == "" {
+		return config.DefaultDepartmentFallback
+	}
+	return utils.SanitizeInlineText(department)
+}
+
+// BuildDepartmentMarkdown 使用内置模板将 commit 列表按院系渲染为「更新内容」段落。
+// 院系按提交数降序排列，院系内部按课程分组，课程内部的渲染与 BuildCourseMarkdown 相同。
+// 涉及多门课程的批量提交单独列在「跨课程批量操作」下。
+func BuildDepartmentMarkdown(commits []CommitEntry, repoTitles, departments map[string]string, orgName string) string {
+	return buildDefaultUpdates(config.LayoutDepartment, commits, repoTitles, departments, orgName)
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+)
+
+func TestDepartmentOf(t *testing.T) {
+	cfg := config.DepartmentsConfig{
+		Prefixes: map[string]string{"EE": "电气工程", "COMP": "计算机", "CROSS": "跨专业课程"},
+		Fallback: "其他",
+	}
+	tests := []struct {
+		repo string
+		meta CourseMeta
+		want string
+	}{
+		{"EE3001", CourseMeta{}, "电气工程"},
+		{"comp3052", CourseMeta{}, "计算机"},
+		{"Cross-EIE", CourseMeta{}, "跨专业课程"},
+		{"MECH2020", CourseMeta{Department: "机电工程与自动化学院"}, "机电工程与自动化学院"},
+		{"legacy-notes", CourseMeta{Code: "COMP1001"}, "计算机"},
+		{"MATH1002", CourseMeta{}, "其他"},
+	}
+	for _, tt := range tests {
+		if got := departmentOf(tt.repo, tt.meta, cfg); got != tt.want {
+			t.Errorf("departmentOf(%q, %+v) = %q, want %q", tt.repo, tt.meta, got, tt.want)
+		}
+	}
+	if got := departmentOf("MATH1002", CourseMeta{}, config.DepartmentsConfig{}); got != "数学" {
+		t.Errorf("expected built-in prefixes when none configured, got %q", got)
+	}
+}
+
+func TestBuildDepartmentMarkdown(t *testing.T) {
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "EE3001"},
+		{AuthorName: "李四", Date: time.Date(2026, 2, 12, 10, 0, 0, 0, time.UTC), Message: "新增实验指导书", RepoName: "EE2002"},
+		{AuthorName: "王五", Date: time.Date(2026, 2, 11, 10, 0, 0, 0, time.UTC), Message: "修正答案错误", RepoName: "COMP3052"},
+		{AuthorName: "赵六", Date: time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC), Message: "统一课程信息", RepoName: "EE3001",
+			Batch: []CommitEntry{{RepoName: "EE3001"}, {RepoName: "COMP3052"}}},
+	}
+	repoTitles := map[string]string{"EE3001": "电路原理", "EE2002": "信号与系统", "COMP3052": "计算机网络"}
+	departments := courseDepartments(commits, nil, config.DepartmentsConfig{
+		Prefixes: map[string]string{"EE": "电气工程", "COMP": "计算机"},
+	})
+
+	result := BuildDepartmentMarkdown(commits, repoTitles, departments, "HITSZ-OpenAuto")
+
+	for _, want := range []string{
+		"### 电气工程（2 条提交）",
+		"#### [电路原理](https://github.com/HITSZ-OpenAuto/EE3001)（1 条提交）",
+		"##### 新增资料",
+		"### 计算机（1 条提交）",
+		"### 跨课程批量操作（1 条）",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("BuildDepartmentMarkdown() missing %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Index(result, "### 电气工程") > strings.Index(result, "### 计算机") {
+		t.Errorf("departments with more commits should come first, got:\n%s", result)
+	}
+}
+
+func TestBuildDailyBody_Departments(t *testing.T) {
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "EE3001"},
+		{AuthorName: "王五", Date: time.Date(2026, 2, 10, 11, 0, 0, 0, time.UTC), Message: "修正答案错误", RepoName: "Cross-EIE"},
+	}
+	departments := courseDepartments(commits, nil, config.DepartmentsConfig{
+		Prefixes: map[string]string{"EE": "电气工程", "CROSS": "跨专业课程"},
+	})
+
+	body := renderDailyBody(t, "HITSZ-OpenAuto", commits, nil, departments, nil, nil, 0)
+	for _, want := range []string{"### 电气工程\n\n#### 新增资料", "### 跨专业课程\n\n#### 勘误修正"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("daily body missing %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// 记录收集阶段被过滤的提交及原因，便于排查，也用于统计自动化操作数
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// droppedCommit 是一条被过滤的提交。
+type droppedCommit struct {
+	Repo    string `json:"repo"`
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Rule    string `json:"rule"`
+	Reason  string `json:"reason"`
+}
+
+// dropRecorder 并发安全地记录被过滤的提交。path 非空时 flush 会将全部记录写入该文件。
+type dropRecorder struct {
+	mu      sync.Mutex
+	path    string
+	dropped []droppedCommit
+}
+
+func newDropRecorder(path string) *dropRecorder {
+	return &dropRecorder{path: path}
+}
+
+func (r *dropRecorder) record(d droppedCommit) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.dropped = append(r.dropped, d)
+	r.mu.Unlock()
+}
+
+// count 返回命中 rules 中任一规则的记录数。
+func (r *dropRecorder) count(rules ...string) int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, d := range r.dropped {
+		for _, rule := range rules {
+			if d.Rule == rule {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// flush 按规则输出过滤统计，并在配置了 path 时写入全部记录。记录按仓库和 SHA 排序以保证内容稳定。
+func (r *dropRecorder) flush() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.dropped) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, d := range r.dropped {
+		counts[d.Rule]++
+	}
+	rules := make([]string, 0, len(counts))
+	for rule, n := range counts {
+		rules = append(rules, fmt.Sprintf("%s=%d", rule, n))
+	}
+	sort.Strings(rules)
+	log.Printf("Dropped %d commits: %s", len(r.dropped), strings.Join(rules, ", "))
+
+	if r.path == "" {
+		return
+	}
+	sort.Slice(r.dropped, func(i, j int) bool {
+		if r.dropped[i].Repo != r.dropped[j].Repo {
+			return r.dropped[i].Repo < r.dropped[j].Repo
+		}
+		return r.dropped[i].SHA < r.dropped[j].SHA
+	})
+	data, err := json.MarshalIndent(r.dropped, "", "  ")
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err == nil {
+			err = os.WriteFile(r.path, append(data, '\n'), 0o644)
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to write drop log %q: %v", r.path, err)
+	}
+}
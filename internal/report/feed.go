@@ -0,0 +1,376 @@
+// 周报归档的 RSS 2.0 和 Atom 订阅源
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	weeklyRoot   = "news/weekly" // 周报归档目录，每期周报一个子目录
+	rssFeedFile  = "feed.xml"
+	atomFeedFile = "atom.xml"
+)
+
+// summaryHeading 返回 lang 语言周报中 AI 摘要段落的标题，如「## 本周更新摘要」。
+func summaryHeading(lang i18n.Lang) string {
+	return "## " + lang.T("weekly.summary")
+}
+
+// feedPost 是订阅源中的一期周报。
+type feedPost struct {
+	Slug        string // 周报目录名，如 weekly-2026-02-08，决定链接和 GUID
+	Title       string
+	Description string
+	Date        time.Time
+	Authors     []utils.Author
+	Body        string // 去掉 front matter 后的 Markdown 正文
+}
+
+// Feed 扫描 news/weekly 下各期周报的 index.md 或 index.mdx，
+// 生成 news/weekly/feed.xml（RSS 2.0）和 news/weekly/atom.xml（Atom）。
+func Feed(cfg *config.Config) error {
+	return writeFeeds(weeklyRoot, cfg.Feed)
+}
+
+// writeFeeds 读取 dir 下的周报并写出两种订阅源。输出只取决于周报内容，重复生成不会产生变更。
+func writeFeeds(dir string, cfg config.FeedConfig) error {
+	posts, err := loadFeedPosts(dir)
+	if err != nil {
+		return err
+	}
+	if cfg.Limit > 0 && len(posts) > cfg.Limit {
+		posts = posts[:cfg.Limit]
+	}
+	if err := writeXML(filepath.Join(dir, rssFeedFile), buildRSS(posts, cfg)); err != nil {
+		return err
+	}
+	if err := writeXML(filepath.Join(dir, atomFeedFile), buildAtom(posts, cfg)); err != nil {
+		return err
+	}
+	log.Printf("Wrote feeds with %d weekly reports", len(posts))
+	return nil
+}
+
+// loadFeedPosts 读取 dir 下每个子目录中的 index.md 或 index.mdx，跳过草稿和没有正文文件的目录，
+// 按日期降序（同一天按目录名降序）返回。
+func loadFeedPosts(dir string) ([]feedPost, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read weekly directory %q: %w", dir, err)
+	}
+	var posts []feedPost
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		for _, name := range []string{"index.md", "index.mdx"} {
+			path := filepath.Join(dir, entry.Name(), name)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to read weekly report %q: %w", path, err)
+			}
+			post, draft, err := parseFeedPost(entry.Name(), data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse weekly report %q: %w", path, err)
+			}
+			if !draft {
+				posts = append(posts, post)
+			}
+			break
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if !posts[i].Date.Equal(posts[j].Date) {
+			return posts[i].Date.After(posts[j].Date)
+		}
+		return posts[i].Slug > posts[j].Slug
+	})
+	return posts, nil
+}
+
+// parseFeedPost 解析周报的 front matter 和正文，标题缺失时使用目录名。
+func parseFeedPost(slug string, data []byte) (post feedPost, draft bool, err error) {
+	fm, body, found, err := splitFrontMatter(string(data))
+	if err != nil {
+		return feedPost{}, false, err
+	}
+	if !found {
+		return feedPost{}, false, errors.New("missing front matter")
+	}
+	date, err := parseFrontMatterDate(fm.Date)
+	if err != nil {
+		return feedPost{}, false, err
+	}
+	post = feedPost{
+		Slug:        slug,
+		Title:       strings.TrimSpace(fm.Title),
+		Description: strings.TrimSpace(fm.Description),
+		Date:        date,
+		Authors:     fm.Authors,
+		Body:        strings.TrimSpace(body),
+	}
+	if post.Title == "" {
+		post.Title = slug
+	}
+	return post, fm.Draft, nil
+}
+
+// splitFrontMatter 拆分 YAML front matter 和正文。没有 front matter 时 found 为 false，body 为全文。
+func splitFrontMatter(content string) (fm utils.FrontMatter, body string, found bool, err error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return utils.FrontMatter{}, content, false, nil
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return utils.FrontMatter{}, "", false, errors.New("unterminated front matter")
+	}
+	if err := yaml.Unmarshal([]byte(content[4:4+end]), &fm); err != nil {
+		return utils.FrontMatter{}, "", false, fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	body = content[4+end+len("\n---"):]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return fm, body, true, nil
+}
+
+// parseFrontMatterDate 解析 front matter 中的日期，只有日期时视为北京时间零点。
+func parseFrontMatterDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if date, err := time.ParseInLocation(layout, value, utils.BeijingTimeZone); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid front matter date %q", value)
+}
+
+// postURL 返回一期周报在网站上的地址，同时用作 RSS GUID 和 Atom ID。
+func postURL(siteURL, slug string) string {
+	return strings.TrimSuffix(siteURL, "/") + "/" + weeklyRoot + "/" + slug + "/"
+}
+
+// feedURL 返回周报归档目录下 name 在网站上的地址，name 为空时即归档首页。
+func feedURL(siteURL, name string) string {
+	return strings.TrimSuffix(siteURL, "/") + "/" + weeklyRoot + "/" + name
+}
+
+// postContent 返回条目的 HTML 内容：full 为完整正文；summary 为 AI 摘要段落，
+// 没有摘要时为 front matter 中的描述。
+func postContent(post feedPost, mode string) string {
+	if mode == config.FeedContentFull {
+		return utils.MarkdownToHTML(post.Body)
+	}
+	if summary := summarySection(post.Body); summary != "" {
+		return utils.MarkdownToHTML(summary)
+	}
+	return "<p>" + html.EscapeString(post.Description) + "</p>\n"
+}
+
+// summarySection 返回正文中「本周更新摘要」标题下的内容，直到下一个二级标题为止，不含标题本身。
+// 订阅源只收录默认语言的周报。
+func summarySection(body string) string {
+	heading := summaryHeading(i18n.Default)
+	start := strings.Index(body, heading)
+	if start < 0 {
+		return ""
+	}
+	rest := body[start+len(heading):]
+	if end := strings.Index(rest, "\n## "); end >= 0 {
+		rest = rest[:end]
+	}
+	return strings.TrimSpace(rest)
+}
+
+// RSS 2.0，见 https://www.rssboard.org/rss-specification
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	Language      string      `xml:"language"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Self          rssAtomLink `xml:"atom:link"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title"`
+	Link        string     `xml:"link"`
+	Description string     `xml:"description"`
+	Content     rssContent `xml:"content:encoded"`
+	GUID        rssGUID    `xml:"guid"`
+	PubDate     string     `xml:"pubDate"`
+}
+
+type rssContent struct {
+	Body string `xml:",cdata"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// buildRSS 构建 RSS 2.0 订阅源，lastBuildDate 取最新一期周报的日期。
+func buildRSS(posts []feedPost, cfg config.FeedConfig) rssFeed {
+	feed := rssFeed{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       cfg.Title,
+			Link:        feedURL(cfg.SiteURL, ""),
+			Description: cfg.Description,
+			Language:    i18n.Default.Tag(),
+			Self:        rssAtomLink{Href: feedURL(cfg.SiteURL, rssFeedFile), Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if len(posts) > 0 {
+		feed.Channel.LastBuildDate = posts[0].Date.Format(time.RFC1123Z)
+	}
+	for _, post := range posts {
+		link := postURL(cfg.SiteURL, post.Slug)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        link,
+			Description: post.Description,
+			Content:     rssContent{Body: postContent(post, cfg.Content)},
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			PubDate:     post.Date.Format(time.RFC1123Z),
+		})
+	}
+	return feed
+}
+
+// Atom，见 RFC 4287
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string       `xml:"title"`
+	ID        string       `xml:"id"`
+	Link      atomLink     `xml:"link"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+	Authors   []atomPerson `xml:"author"`
+	Summary   string       `xml:"summary,omitempty"`
+	Content   atomText     `xml:"content"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// buildAtom 构建 Atom 订阅源，feed 级作者为组织，条目作者取自周报 front matter。
+// 没有周报时 updated 为 Unix 零点，保证输出稳定。
+func buildAtom(posts []feedPost, cfg config.FeedConfig) atomFeed {
+	home := feedURL(cfg.SiteURL, "")
+	updated := time.Unix(0, 0).UTC()
+	if len(posts) > 0 {
+		updated = posts[0].Date
+	}
+	feed := atomFeed{
+		Title:    cfg.Title,
+		Subtitle: cfg.Description,
+		ID:       home,
+		Updated:  updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feedURL(cfg.SiteURL, atomFeedFile), Rel: "self", Type: "application/atom+xml"},
+			{Href: home, Rel: "alternate", Type: "text/html"},
+		},
+		Author: atomPerson{Name: config.OrgName, URI: "https://github.com/" + config.OrgName},
+	}
+	for _, post := range posts {
+		link := postURL(cfg.SiteURL, post.Slug)
+		entry := atomEntry{
+			Title:     post.Title,
+			ID:        link,
+			Link:      atomLink{Href: link, Rel: "alternate", Type: "text/html"},
+			Published: post.Date.Format(time.RFC3339),
+			Updated:   post.Date.Format(time.RFC3339),
+			Summary:   post.Description,
+			Content:   atomText{Type: "html", Body: postContent(post, cfg.Content)},
+		}
+		for _, author := range post.Authors {
+			if name := strings.TrimSpace(author.Name); name != "" {
+				entry.Authors = append(entry.Authors, atomPerson{Name: name, URI: author.Link})
+			}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+// writeXML 将 v 编码为带 XML 声明的缩进文档并写入 path。
+func writeXML(path string, v any) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	enc := xml.NewEncoder(&b)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode feed %q: %w", path, err)
+	}
+	b.WriteByte('\n')
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write feed %q: %w", path, err)
+	}
+	return nil
+}
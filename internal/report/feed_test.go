@@ -0,0 +1,311 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+)
+
+func writeWeeklyPost(t *testing.T, dir, slug, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, slug), 0o755); err != nil {
+		t.Fatalf("failed to create post directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, slug, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write post: %v", err)
+	}
+}
+
+func testFeedConfig() config.FeedConfig {
+	return config.FeedConfig{
+		SiteURL:     "https://hoa.moe/",
+		Title:       "AUTO 周报",
+		Description: "每周更新",
+		Content:     config.FeedContentFull,
+		Limit:       20,
+	}
+}
+
+func setupWeeklyArchive(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeWeeklyPost(t, dir, "weekly-2026-02-01", "index.md", "---\ntitle: AUTO 周报 2026-02-01 - 2026-02-08\ndate: \"2026-02-08\"\n"+
+		"authors:\n  - name: ChatGPT\n    link: https://github.com/openai\ndescription: 涵盖 2026-02-01 至 2026-02-08 的更新\n---\n\n"+
+		"## 本周更新摘要\n\n- **电路原理**：新增期末试卷 & 答案\n\n## 更新内容\n\n### 周二 (2.3)\n\n- 张三 在 [电路原理](https://github.com/HITSZ-OpenAuto/EE3001) 中提交了信息：上传试卷 ]]> 结尾\n")
+	writeWeeklyPost(t, dir, "weekly-2026-02-08", "index.mdx", "---\ntitle: AUTO 周报 2026-02-08 - 2026-02-15\ndate: 2026-02-15\n"+
+		"description: 涵盖 2026-02-08 至 2026-02-15 的更新\n---\n\n## 更新内容\n\n- 李四 更新了 <课程> 信息\n")
+	writeWeeklyPost(t, dir, "weekly-draft", "index.md", "---\ntitle: 草稿\ndate: 2026-03-01\ndraft: true\n---\n正文\n")
+	if err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("---\ntitle: AUTO 周报\n---\n"), 0o644); err != nil {
+		t.Fatalf("failed to write weekly index: %v", err)
+	}
+	return dir
+}
+
+func TestWriteFeeds(t *testing.T) {
+	dir := setupWeeklyArchive(t)
+	if err := writeFeeds(dir, testFeedConfig()); err != nil {
+		t.Fatalf("writeFeeds() returned error: %v", err)
+	}
+	rss, err := os.ReadFile(filepath.Join(dir, rssFeedFile))
+	if err != nil {
+		t.Fatalf("failed to read RSS feed: %v", err)
+	}
+	atom, err := os.ReadFile(filepath.Join(dir, atomFeedFile))
+	if err != nil {
+		t.Fatalf("failed to read Atom feed: %v", err)
+	}
+	for _, problem := range validateRSS(rss) {
+		t.Errorf("RSS: %s", problem)
+	}
+	for _, problem := range validateAtom(atom) {
+		t.Errorf("Atom: %s", problem)
+	}
+
+	var feed rssFeedDoc
+	if err := xml.Unmarshal(rss, &feed); err != nil {
+		t.Fatalf("failed to decode RSS: %v", err)
+	}
+	items := feed.Channel.Items
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items (draft skipped), got %d", len(items))
+	}
+	if items[0].GUID != "https://hoa.moe/news/weekly/weekly-2026-02-08/" || items[1].GUID != "https://hoa.moe/news/weekly/weekly-2026-02-01/" {
+		t.Errorf("unexpected GUIDs or order: %q, %q", items[0].GUID, items[1].GUID)
+	}
+	if !strings.Contains(items[0].Content, "&lt;课程&gt;") {
+		t.Errorf("expected escaped text in content, got %q", items[0].Content)
+	}
+	if !strings.Contains(items[1].Content, "<strong>电路原理</strong>") || !strings.Contains(items[1].Content, "上传试卷 ]]&gt; 结尾") {
+		t.Errorf("expected full content rendered to HTML, got %q", items[1].Content)
+	}
+
+	// 重复生成的输出完全相同，GUID 不随生成时间变化
+	if err := writeFeeds(dir, testFeedConfig()); err != nil {
+		t.Fatalf("writeFeeds() returned error: %v", err)
+	}
+	again, err := os.ReadFile(filepath.Join(dir, rssFeedFile))
+	if err != nil {
+		t.Fatalf("failed to read RSS feed: %v", err)
+	}
+	if !bytes.Equal(rss, again) {
+		t.Errorf("feed output is not stable across runs")
+	}
+}
+
+func TestWriteFeeds_SummaryAndLimit(t *testing.T) {
+	dir := setupWeeklyArchive(t)
+	cfg := testFeedConfig()
+	cfg.Content = config.FeedContentSummary
+	cfg.Limit = 1
+	writeWeeklyPost(t, dir, "weekly-2026-02-08", "index.mdx", "---\ntitle: 第二期\ndate: 2026-02-15\ndescription: 只有描述\n---\n\n## 更新内容\n")
+	if err := writeFeeds(dir, cfg); err != nil {
+		t.Fatalf("writeFeeds() returned error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, atomFeedFile))
+	if err != nil {
+		t.Fatalf("failed to read Atom feed: %v", err)
+	}
+	for _, problem := range validateAtom(data) {
+		t.Errorf("Atom: %s", problem)
+	}
+	var feed atomFeedDoc
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		t.Fatalf("failed to decode Atom: %v", err)
+	}
+	if len(feed.Entries) != 1 || feed.Entries[0].Content.Body != "<p>只有描述</p>\n" {
+		t.Errorf("expected one entry with description fallback, got %+v", feed.Entries)
+	}
+
+	if got := postContent(feedPost{Body: "## 本周更新摘要\n\n- 摘要\n\n## 更新内容\n\n- 详情"}, config.FeedContentSummary); got != "<ul>\n<li>摘要</li>\n</ul>\n" {
+		t.Errorf("postContent() = %q, want only the summary section", got)
+	}
+}
+
+func TestParseFeedPost_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"no front matter": "# 标题\n",
+		"unterminated":    "---\ntitle: x\n",
+		"bad date":        "---\ntitle: x\ndate: 下周五\n---\n",
+	} {
+		if _, _, err := parseFeedPost("weekly-x", []byte(content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// 以下为测试用的简易订阅源校验，覆盖 RSS 2.0 规范和 RFC 4287 中的必填项与格式要求。
+
+type rssFeedDoc struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel struct {
+		// atom:link 需要声明在 link 之前，否则会被不带命名空间的 link 字段匹配
+		Self []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"http://www.w3.org/2005/Atom link"`
+		Title         string `xml:"title"`
+		Link          string `xml:"link"`
+		Description   string `xml:"description"`
+		LastBuildDate string `xml:"lastBuildDate"`
+		Items         []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			Content     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+			GUID        string `xml:"guid"`
+			PubDate     string `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+type atomFeedDoc struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Links   []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Authors []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Entries []struct {
+		ID      string `xml:"id"`
+		Title   string `xml:"title"`
+		Updated string `xml:"updated"`
+		Links   []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Authors []struct {
+			Name string `xml:"name"`
+		} `xml:"author"`
+		Content struct {
+			Type string `xml:"type,attr"`
+			Body string `xml:",chardata"`
+		} `xml:"content"`
+	} `xml:"entry"`
+}
+
+// checkWellFormed 以严格模式读取全部 token，并检查使用的命名空间前缀均已声明。
+func checkWellFormed(data []byte) []string {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = true
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return []string{fmt.Sprintf("not well-formed: %v", err)}
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Space != "" && !strings.Contains(start.Name.Space, "://") {
+			return []string{fmt.Sprintf("undeclared namespace prefix %q", start.Name.Space)}
+		}
+	}
+}
+
+func checkAbsoluteURL(field, raw string) []string {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return []string{fmt.Sprintf("%s is not an absolute URL: %q", field, raw)}
+	}
+	return nil
+}
+
+func validateRSS(data []byte) []string {
+	problems := checkWellFormed(data)
+	var feed rssFeedDoc
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return append(problems, fmt.Sprintf("failed to decode: %v", err))
+	}
+	if feed.Version != "2.0" {
+		problems = append(problems, fmt.Sprintf("version = %q, want 2.0", feed.Version))
+	}
+	ch := feed.Channel
+	if ch.Title == "" || ch.Description == "" {
+		problems = append(problems, "channel title and description are required")
+	}
+	problems = append(problems, checkAbsoluteURL("channel link", ch.Link)...)
+	if len(ch.Self) != 1 || ch.Self[0].Rel != "self" {
+		problems = append(problems, "channel should have one atom:link rel=self")
+	}
+	if ch.LastBuildDate != "" {
+		if _, err := time.Parse(time.RFC1123Z, ch.LastBuildDate); err != nil {
+			problems = append(problems, fmt.Sprintf("lastBuildDate is not RFC 822: %q", ch.LastBuildDate))
+		}
+	}
+	guids := make(map[string]bool)
+	for i, item := range ch.Items {
+		if item.Title == "" && item.Description == "" {
+			problems = append(problems, fmt.Sprintf("item %d needs a title or description", i))
+		}
+		problems = append(problems, checkAbsoluteURL(fmt.Sprintf("item %d link", i), item.Link)...)
+		if item.GUID == "" || guids[item.GUID] {
+			problems = append(problems, fmt.Sprintf("item %d has empty or duplicate guid %q", i, item.GUID))
+		}
+		guids[item.GUID] = true
+		if _, err := time.Parse(time.RFC1123Z, item.PubDate); err != nil {
+			problems = append(problems, fmt.Sprintf("item %d pubDate is not RFC 822: %q", i, item.PubDate))
+		}
+	}
+	return problems
+}
+
+func validateAtom(data []byte) []string {
+	problems := checkWellFormed(data)
+	var feed atomFeedDoc
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return append(problems, fmt.Sprintf("failed to decode: %v", err))
+	}
+	if feed.ID == "" || feed.Title == "" {
+		problems = append(problems, "feed id and title are required")
+	}
+	if _, err := time.Parse(time.RFC3339, feed.Updated); err != nil {
+		problems = append(problems, fmt.Sprintf("feed updated is not RFC 3339: %q", feed.Updated))
+	}
+	hasSelf := false
+	for _, link := range feed.Links {
+		hasSelf = hasSelf || link.Rel == "self"
+	}
+	if !hasSelf {
+		problems = append(problems, "feed should have a self link")
+	}
+	ids := make(map[string]bool)
+	for i, entry := range feed.Entries {
+		if entry.ID == "" || ids[entry.ID] {
+			problems = append(problems, fmt.Sprintf("entry %d has empty or duplicate id %q", i, entry.ID))
+		}
+		ids[entry.ID] = true
+		if entry.Title == "" {
+			problems = append(problems, fmt.Sprintf("entry %d title is required", i))
+		}
+		if _, err := time.Parse(time.RFC3339, entry.Updated); err != nil {
+			problems = append(problems, fmt.Sprintf("entry %d updated is not RFC 3339: %q", i, entry.Updated))
+		}
+		if len(feed.Authors) == 0 && len(entry.Authors) == 0 {
+			problems = append(problems, fmt.Sprintf("entry %d has no author and the feed has none either", i))
+		}
+		if len(entry.Links) == 0 {
+			problems = append(problems, fmt.Sprintf("entry %d needs an alternate link", i))
+		}
+		for _, link := range entry.Links {
+			problems = append(problems, checkAbsoluteURL(fmt.Sprintf("entry %d link", i), link.Href)...)
+		}
+		if entry.Content.Type != "html" {
+			problems = append(problems, fmt.Sprintf("entry %d content type = %q, want html", i, entry.Content.Type))
+		}
+	}
+	return problems
+}
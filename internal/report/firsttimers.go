@@ -0,0 +1,239 @@
+// 新贡献者识别：找出第一次向组织内任意仓库提交的贡献者
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+const firstContributionsCacheFile = "first-contributions.json"
+
+// commit 搜索 API 每分钟限 30 次请求，见 https://docs.github.com/rest/search/search#rate-limit
+const (
+	searchInterval    = 2 * time.Second // 两次搜索请求的最小间隔
+	searchRetries     = 3               // 被限流后的最多重试次数
+	searchDefaultWait = time.Minute     // 响应头没有给出等待时间时的等待时间
+)
+
+// FirstTimer 表示在报告时间窗口内完成第一次贡献的贡献者。
+type FirstTimer struct {
+	First CommitEntry // 窗口内最早的一条提交
+	Total int         // 窗口内的提交总数
+}
+
+// firstContribution 记录贡献者在组织内的第一次提交。
+type firstContribution struct {
+	Date time.Time `json:"date"`
+	Repo string    `json:"repo"`
+}
+
+// FirstContributionCache 缓存每位贡献者的第一次提交，避免每次运行都调用搜索 API。
+// 键为 contributorKey 的返回值。
+type FirstContributionCache struct {
+	Contributors map[string]firstContribution `json:"contributors"`
+}
+
+// earliestCommitLookup 查询贡献者在 before 之前的第一次提交，found 为 false 表示此前没有提交。
+type earliestCommitLookup func(author CommitEntry, before time.Time) (first firstContribution, found bool, err error)
+
+// LoadFirstContributionCache 读取缓存文件，文件不存在时返回空缓存。
+func LoadFirstContributionCache(path string) (*FirstContributionCache, error) {
+	cache := &FirstContributionCache{Contributors: make(map[string]firstContribution)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+	if cache.Contributors == nil {
+		cache.Contributors = make(map[string]firstContribution)
+	}
+	return cache, nil
+}
+
+// Save 将缓存写入 path，键按字母序输出以保证内容稳定。
+func (c *FirstContributionCache) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// detectFirstTimers 读取缓存、识别新贡献者并写回缓存。
+// 识别失败不会中断周报生成，只会记录日志并返回空结果。
+func detectFirstTimers(orgName string, commits []CommitEntry, windowStart time.Time, cacheDir string) []FirstTimer {
+	path := filepath.Join(cacheDir, firstContributionsCacheFile)
+	cache, err := LoadFirstContributionCache(path)
+	if err != nil {
+		log.Printf("Failed to load first contribution cache: %v", err)
+		return nil
+	}
+	search := throttledSearch(github.SearchEarliestCommit, searchInterval, searchRetries, time.Sleep)
+	firstTimers := findFirstTimers(commits, windowStart, cache, searchEarliestCommit(orgName, search))
+	if err := cache.Save(path); err != nil {
+		log.Printf("Failed to save first contribution cache: %v", err)
+	}
+	log.Printf("Detected %d first-time contributors", len(firstTimers))
+	return firstTimers
+}
+
+// findFirstTimers 找出第一次提交落在 windowStart 之后的贡献者，并更新缓存。
+// 缓存未命中的贡献者通过 lookup 查询其历史；查询失败的贡献者本次跳过并记录日志，不写入缓存。
+// 返回结果按第一次提交的时间排序。
+func findFirstTimers(commits []CommitEntry, windowStart time.Time, cache *FirstContributionCache, lookup earliestCommitLookup) []FirstTimer {
+	byAuthor := make(map[string]*FirstTimer)
+	for _, commit := range commits {
+		key := contributorKey(commit)
+		ft, ok := byAuthor[key]
+		if !ok {
+			ft = &FirstTimer{First: commit}
+			byAuthor[key] = ft
+		}
+		ft.Total++
+		if commit.Date.Before(ft.First.Date) {
+			ft.First = commit
+		}
+	}
+
+	firstTimers := make([]FirstTimer, 0)
+	skipped := 0
+	for key, ft := range byAuthor {
+		known, ok := cache.Contributors[key]
+		if !ok {
+			first, found, err := lookup(ft.First, windowStart)
+			if err != nil {
+				log.Printf("Skipped first-timer check for %s, history lookup failed: %v", key, err)
+				skipped++
+				continue
+			}
+			if !found {
+				first = firstContribution{Date: ft.First.Date, Repo: ft.First.RepoName}
+			}
+			cache.Contributors[key] = first
+			known = first
+		}
+		if known.Date.Before(windowStart) {
+			continue
+		}
+		firstTimers = append(firstTimers, *ft)
+	}
+	if skipped > 0 {
+		log.Printf("Skipped %d contributors whose history could not be looked up, they are not welcomed in this report", skipped)
+	}
+
+	sort.Slice(firstTimers, func(i, j int) bool {
+		if !firstTimers[i].First.Date.Equal(firstTimers[j].First.Date) {
+			return firstTimers[i].First.Date.Before(firstTimers[j].First.Date)
+		}
+		return contributorKey(firstTimers[i].First) < contributorKey(firstTimers[j].First)
+	})
+	return firstTimers
+}
+
+// commitSearch 在组织内搜索最早的 commit，签名与 github.SearchEarliestCommit 相同。
+type commitSearch func(orgName, query string) (github.CommitSearchResult, error)
+
+// throttledSearch 串行执行 search，两次请求之间至少间隔 interval；
+// 被限流时按响应头给出的时间等待后重试，最多重试 retries 次。
+func throttledSearch(search commitSearch, interval time.Duration, retries int, sleep func(time.Duration)) commitSearch {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	return func(orgName, query string) (github.CommitSearchResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for attempt := 0; ; attempt++ {
+			if wait := interval - time.Since(last); !last.IsZero() && wait > 0 {
+				sleep(wait)
+			}
+			result, err := search(orgName, query)
+			last = time.Now()
+			var limited *github.RateLimitError
+			if !errors.As(err, &limited) || attempt >= retries {
+				return result, err
+			}
+			wait := limited.RetryAfter
+			if wait <= 0 {
+				wait = searchDefaultWait
+			}
+			log.Printf("Commit search rate limited, retrying in %s (%d/%d)", wait, attempt+1, retries)
+			sleep(wait)
+		}
+	}
+}
+
+// searchEarliestCommit 返回基于 GitHub commit 搜索 API 的 earliestCommitLookup。
+// 有登录名时按登录名搜索，否则按作者名搜索。
+func searchEarliestCommit(orgName string, search commitSearch) earliestCommitLookup {
+	return func(author CommitEntry, before time.Time) (firstContribution, bool, error) {
+		var query string
+		if author.AuthorLogin != "" {
+			query = "author:" + author.AuthorLogin
+		} else {
+			query = fmt.Sprintf("author-name:%q", author.AuthorName)
+		}
+		query += " author-date:<" + before.UTC().Format(time.RFC3339)
+
+		result, err := search(orgName, query)
+		if err != nil {
+			return firstContribution{}, false, err
+		}
+		if result.TotalCount == 0 || len(result.Items) == 0 {
+			return firstContribution{}, false, nil
+		}
+		item := result.Items[0]
+		date, err := time.Parse(time.RFC3339, item.Commit.Author.Date)
+		if err != nil {
+			return firstContribution{}, false, err
+		}
+		return firstContribution{Date: date.In(utils.BeijingTimeZone), Repo: item.Repository.Name}, true, nil
+	}
+}
+
+// contributorKey 返回识别贡献者所用的键：优先使用登录名，否则使用作者名。
+func contributorKey(commit CommitEntry) string {
+	if commit.AuthorLogin != "" {
+		return "login:" + strings.ToLower(commit.AuthorLogin)
+	}
+	return "name:" + strings.ToLower(strings.TrimSpace(commit.AuthorName))
+}
+
+// newFirstTimerViews 将新贡献者转换为 lang 语言的模板数据。
+func newFirstTimerViews(lang i18n.Lang, firstTimers []FirstTimer, repoTitles map[string]string, orgName string) []FirstTimerView {
+	views := make([]FirstTimerView, 0, len(firstTimers))
+	for _, ft := range firstTimers {
+		commit := ft.First
+		title := utils.SanitizeLinkLabel(courseTitle(commit.RepoName, repoTitles))
+		views = append(views, FirstTimerView{
+			Author:   renderAuthor(lang, commit),
+			Location: fmt.Sprintf("[%s](https://github.com/%s/%s)", title, orgName, commit.RepoName),
+			Message:  renderMessage(commit),
+			Date:     lang.ShortDate(commit.Date),
+			Total:    ft.Total,
+			Course:   commit.Course,
+		})
+	}
+	return views
+}
@@ -0,0 +1,199 @@
+package report
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+)
+
+func TestFindFirstTimers(t *testing.T) {
+	windowStart := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
+	commits := []CommitEntry{
+		{AuthorName: "新人", AuthorLogin: "newbie", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "补充笔记", RepoName: "COMP3052"},
+		{AuthorName: "新人", AuthorLogin: "newbie", Date: time.Date(2026, 2, 8, 10, 0, 0, 0, time.UTC), Message: "上传试卷", RepoName: "EE3001"},
+		{AuthorName: "老人", AuthorLogin: "veteran", Date: time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC), Message: "修复错误", RepoName: "EE3001"},
+		{AuthorName: "缓存老人", AuthorLogin: "cached", Date: time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC), Message: "修复错误", RepoName: "EE3001"},
+		{AuthorName: "查询失败", Date: time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC), Message: "修复错误", RepoName: "EE3001"},
+	}
+	cache := &FirstContributionCache{Contributors: map[string]firstContribution{
+		"login:cached": {Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Repo: "EE3001"},
+	}}
+
+	var (
+		lookedUp   []string
+		lastBefore time.Time
+	)
+	lookup := func(author CommitEntry, before time.Time) (firstContribution, bool, error) {
+		lookedUp = append(lookedUp, contributorKey(author))
+		lastBefore = before
+		switch author.AuthorLogin {
+		case "veteran":
+			return firstContribution{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Repo: "MATH1002"}, true, nil
+		case "newbie":
+			return firstContribution{}, false, nil
+		}
+		return firstContribution{}, false, errors.New("rate limited")
+	}
+
+	got := findFirstTimers(commits, windowStart, cache, lookup)
+	if !lastBefore.Equal(windowStart) {
+		t.Errorf("lookup called with before=%s, want %s", lastBefore, windowStart)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected exactly 1 first-timer, got %+v", got)
+	}
+	if got[0].First.AuthorLogin != "newbie" || got[0].First.Message != "上传试卷" || got[0].Total != 2 {
+		t.Errorf("unexpected first-timer: %+v", got[0])
+	}
+	for _, key := range lookedUp {
+		if key == "login:cached" {
+			t.Errorf("expected cached contributor to skip lookup")
+		}
+	}
+	if first := cache.Contributors["login:newbie"]; first.Repo != "EE3001" || !first.Date.Equal(commits[1].Date) {
+		t.Errorf("expected newbie's first contribution cached, got %+v", first)
+	}
+	if _, ok := cache.Contributors["name:查询失败"]; ok {
+		t.Errorf("failed lookups must not be cached")
+	}
+
+	// 再次运行时直接命中缓存，结果保持一致
+	again := findFirstTimers(commits, windowStart, cache, func(author CommitEntry, before time.Time) (firstContribution, bool, error) {
+		if author.AuthorName != "查询失败" {
+			t.Errorf("unexpected lookup for %s", contributorKey(author))
+		}
+		return firstContribution{}, false, errors.New("rate limited")
+	})
+	if len(again) != 1 || again[0].First.AuthorLogin != "newbie" {
+		t.Errorf("expected cached result to be stable, got %+v", again)
+	}
+
+	// 下一周 newbie 就不再是新贡献者
+	if next := findFirstTimers(commits, windowStart.AddDate(0, 0, 7), cache, lookup); len(next) != 0 {
+		t.Errorf("expected no first-timers in next window, got %+v", next)
+	}
+}
+
+func TestFirstContributionCache_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", firstContributionsCacheFile)
+
+	empty, err := LoadFirstContributionCache(path)
+	if err != nil {
+		t.Fatalf("LoadFirstContributionCache() returned error: %v", err)
+	}
+	if len(empty.Contributors) != 0 {
+		t.Fatalf("expected empty cache for missing file")
+	}
+
+	date := time.Date(2026, 2, 8, 10, 0, 0, 0, time.UTC)
+	empty.Contributors["login:newbie"] = firstContribution{Date: date, Repo: "EE3001"}
+	if err := empty.Save(path); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := LoadFirstContributionCache(path)
+	if err != nil {
+		t.Fatalf("LoadFirstContributionCache() returned error: %v", err)
+	}
+	if got := loaded.Contributors["login:newbie"]; got.Repo != "EE3001" || !got.Date.Equal(date) {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+}
+
+func TestThrottledSearch(t *testing.T) {
+	var (
+		calls  int
+		sleeps []time.Duration
+	)
+	search := func(orgName, query string) (github.CommitSearchResult, error) {
+		calls++
+		switch {
+		case query == "busy" && calls == 1:
+			return github.CommitSearchResult{}, &github.RateLimitError{RetryAfter: 30 * time.Second}
+		case query == "busy" && calls == 2:
+			return github.CommitSearchResult{}, &github.RateLimitError{} // 未给出等待时间时使用默认值
+		case query == "broken":
+			return github.CommitSearchResult{}, errors.New("HTTP 422")
+		case query == "down":
+			return github.CommitSearchResult{}, &github.RateLimitError{RetryAfter: time.Second}
+		}
+		return github.CommitSearchResult{TotalCount: 1}, nil
+	}
+	throttled := throttledSearch(search, time.Hour, 2, func(d time.Duration) { sleeps = append(sleeps, d) })
+
+	if result, err := throttled("HITSZ-OpenAuto", "busy"); err != nil || result.TotalCount != 1 || calls != 3 {
+		t.Fatalf("expected success after two retries, got %+v, %v after %d calls", result, err, calls)
+	}
+	if len(sleeps) < 2 || sleeps[0] != 30*time.Second || sleeps[1] < searchDefaultWait {
+		t.Errorf("unexpected waits %v, want Retry-After first and the default wait next", sleeps)
+	}
+	for _, d := range sleeps[2:] {
+		if d <= 0 || d > time.Hour {
+			t.Errorf("interval wait %v out of range", d)
+		}
+	}
+
+	calls, sleeps = 0, nil
+	if _, err := throttled("HITSZ-OpenAuto", "broken"); err == nil || calls != 1 {
+		t.Errorf("non rate limit errors should not be retried, calls = %d, err = %v", calls, err)
+	}
+	if len(sleeps) != 1 {
+		t.Errorf("consecutive searches should be spaced by the interval, waits = %v", sleeps)
+	}
+
+	calls = 0
+	var limited *github.RateLimitError
+	if _, err := throttled("HITSZ-OpenAuto", "down"); !errors.As(err, &limited) || calls != 3 {
+		t.Errorf("expected rate limit error after 2 retries, calls = %d, err = %v", calls, err)
+	}
+}
+
+func TestWeeklyFirstTimersTemplate(t *testing.T) {
+	render := func(firstTimers []FirstTimer, repoTitles map[string]string) string {
+		views := newFirstTimerViews(i18n.Default, firstTimers, repoTitles, "HITSZ-OpenAuto")
+		return defaultRenderer.mustExecute("weekly-first-timers", WeeklyData{Org: "HITSZ-OpenAuto", FirstTimers: views})
+	}
+	if got := render(nil, nil); got != "" {
+		t.Errorf("expected empty section without first-timers, got %q", got)
+	}
+
+	section := render([]FirstTimer{{
+		First: CommitEntry{AuthorName: "新人", AuthorLogin: "newbie", Date: time.Date(2026, 2, 8, 10, 0, 0, 0, time.UTC), Message: "上传试卷", RepoName: "EE3001"},
+		Total: 3,
+	}}, map[string]string{"EE3001": "电路原理"})
+
+	want := "- [新人](https://github.com/newbie) 在 [电路原理](https://github.com/HITSZ-OpenAuto/EE3001) 中完成了第一次贡献：上传试卷 (2.8)，本周共提交 3 次"
+	if !strings.HasPrefix(section, "## 欢迎新贡献者") || !strings.Contains(section, want) {
+		t.Errorf("unexpected section:\n%s", section)
+	}
+}
+
+func TestWeeklyStatsTemplate(t *testing.T) {
+	agg := WeeklyAggregate{
+		Commits: []CommitEntry{
+			{AuthorName: "A", AuthorLogin: "a", RepoName: "r1"},
+			{AuthorName: "A", AuthorLogin: "a", RepoName: "r2"},
+			{AuthorName: "B", RepoName: "r1"},
+		},
+		FirstTimers: []FirstTimer{{}},
+	}
+	section := defaultRenderer.mustExecute("weekly-stats", newStatsView(agg))
+	for _, want := range []string{"- **提交数**: 3", "- **贡献者**: 2", "- **涉及课程**: 2", "- **新贡献者**: 1"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("stats section missing %q:\n%s", want, section)
+		}
+	}
+	if strings.Contains(section, "自动化操作") {
+		t.Errorf("automation count should be omitted when zero:\n%s", section)
+	}
+
+	agg.Automated = 7
+	if section := defaultRenderer.mustExecute("weekly-stats", newStatsView(agg)); !strings.Contains(section, "- **自动化操作**: 7") {
+		t.Errorf("stats section missing automation count:\n%s", section)
+	}
+}
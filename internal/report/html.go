@@ -0,0 +1,123 @@
+// 独立 HTML 页面：将报告 Markdown 渲染为带内联样式和目录的单个 HTML 文件，
+// 用于邮件正文、本地预览和归档
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+//go:embed templates/html/page.html.tmpl
+var pageTemplateSource string
+
+var pageTemplate = htmltemplate.Must(htmltemplate.New("page").Parse(pageTemplateSource))
+
+var (
+	htmlHeadingRe = regexp.MustCompile(`<h([23])>(.*)</h[23]>`)
+	htmlTagRe     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// HTMLPage 是 HTML 页面模板的数据，除 Body 外的字段均由 html/template 转义。
+type HTMLPage struct {
+	Title       string
+	Description string
+	Date        string
+	Org         string
+	TOC         []TOCEntry
+	Body        htmltemplate.HTML // 由 utils.MarkdownToHTML 转换的正文，外部文本在生成 Markdown 时已转义
+}
+
+// TOCEntry 是目录中的一项，二级标题下挂三级标题。
+type TOCEntry struct {
+	ID       string
+	Text     string // 纯文本标题
+	Children []TOCEntry
+}
+
+// RenderHTML 将报告 Markdown（可带 front matter）渲染为独立的 HTML 页面。
+// 标题取自 front matter，缺失时使用 fallbackTitle。
+func RenderHTML(markdown, fallbackTitle string) (string, error) {
+	fm, body, _, err := splitFrontMatter(markdown)
+	if err != nil {
+		return "", err
+	}
+	title := strings.TrimSpace(fm.Title)
+	if title == "" {
+		title = fallbackTitle
+	}
+	return renderHTMLPage(title, strings.TrimSpace(fm.Description), strings.TrimSpace(fm.Date), body)
+}
+
+// renderHTMLPage 将不含 front matter 的 Markdown 正文渲染为独立的 HTML 页面。
+func renderHTMLPage(title, description, date, body string) (string, error) {
+	content, toc := addHeadingAnchors(utils.MarkdownToHTML(body))
+	page := HTMLPage{
+		Title:       title,
+		Description: description,
+		Date:        date,
+		Org:         config.OrgName,
+		TOC:         toc,
+		Body:        htmltemplate.HTML(content),
+	}
+	var b strings.Builder
+	if err := pageTemplate.Execute(&b, page); err != nil {
+		return "", fmt.Errorf("failed to render HTML page: %w", err)
+	}
+	return b.String(), nil
+}
+
+// addHeadingAnchors 为二、三级标题添加 id，并按出现顺序生成目录。
+// 出现在第一个二级标题之前的三级标题作为顶层条目。
+func addHeadingAnchors(content string) (string, []TOCEntry) {
+	var toc []TOCEntry
+	n := 0
+	content = htmlHeadingRe.ReplaceAllStringFunc(content, func(heading string) string {
+		m := htmlHeadingRe.FindStringSubmatch(heading)
+		n++
+		entry := TOCEntry{
+			ID:   fmt.Sprintf("section-%d", n),
+			Text: html.UnescapeString(htmlTagRe.ReplaceAllString(m[2], "")),
+		}
+		if m[1] == "3" && len(toc) > 0 {
+			last := &toc[len(toc)-1]
+			last.Children = append(last.Children, entry)
+		} else {
+			toc = append(toc, entry)
+		}
+		return fmt.Sprintf(`<h%s id="%s">%s</h%s>`, m[1], entry.ID, m[2], m[1])
+	})
+	return content, toc
+}
+
+// WriteHTML 读取 input 处的报告 Markdown，渲染为 HTML 后写入 output；
+// output 为空时写到 input 旁的同名 .html 文件。
+func WriteHTML(input, output string) error {
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read report %q: %w", input, err)
+	}
+	if output == "" {
+		output = strings.TrimSuffix(input, filepath.Ext(input)) + ".html"
+	}
+	title := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+	if title == "index" {
+		title = filepath.Base(filepath.Dir(input)) // 周报等目录形式的页面使用目录名
+	}
+	page, err := RenderHTML(string(data), title)
+	if err != nil {
+		return fmt.Errorf("failed to render %q: %w", input, err)
+	}
+	if err := os.WriteFile(output, []byte(page), 0o644); err != nil {
+		return fmt.Errorf("failed to write HTML page %q: %w", output, err)
+	}
+	return nil
+}
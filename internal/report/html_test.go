@@ -0,0 +1,107 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+)
+
+func TestRenderHTML_NoRawHTMLFromUserText(t *testing.T) {
+	commits := []CommitEntry{
+		{AuthorName: "<img src=x onerror=alert(1)>", Date: time.Date(2026, 2, 10, 2, 0, 0, 0, time.UTC),
+			Message: `<script>alert(1)</script> 上传试卷`, RepoName: "EE3001", SHA: "abc"},
+		{AuthorName: "李四", AuthorLogin: "lisi", Date: time.Date(2026, 2, 10, 3, 0, 0, 0, time.UTC),
+			Message: "[点我](javascript:alert(1)) 修正答案", RepoName: "EE3001", SHA: "def"},
+	}
+	issues := []github.Item{{
+		Title: `"><svg onload=alert(1)>`, URL: "https://github.com/HITSZ-OpenAuto/EE3001/issues/1",
+		Repository: github.Repository{Name: "EE3001"}, CreatedAt: "2026-02-09T08:00:00Z", Author: github.Author{Login: "reader"},
+	}}
+	body := renderDailyBody(t, "HITSZ-OpenAuto", commits, map[string]string{"EE3001": "<b>电路</b>"}, nil, issues, nil, 0)
+	markdown := "---\ntitle: \"AUTO 更新速递 <i>\"\ndate: 2026-02-10\n---\n\n" + body
+
+	page, err := RenderHTML(markdown, "daily")
+	if err != nil {
+		t.Fatalf("RenderHTML() returned error: %v", err)
+	}
+	for _, bad := range []string{"<script", "<img", "<svg", "<b>", "<i>", "javascript:"} {
+		if strings.Contains(page, bad) {
+			t.Errorf("page contains raw %q:\n%s", bad, page)
+		}
+	}
+	for _, want := range []string{"&lt;script&gt;alert(1)&lt;/script&gt; 上传试卷", "点我 修正答案", "<title>AUTO 更新速递 &lt;i&gt;</title>"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestRenderHTML_TagLineWithTrailingMarkup(t *testing.T) {
+	markdown := "<details><img src=x onerror=alert(1)>\n<summary>查看全部</summary><script>alert(1)</script>\n\n- 条目\n\n</details>\n"
+	page, err := RenderHTML(markdown, "预览")
+	if err != nil {
+		t.Fatalf("RenderHTML() returned error: %v", err)
+	}
+	for _, bad := range []string{"<img", "<script"} {
+		if strings.Contains(page, bad) {
+			t.Errorf("page contains raw %q:\n%s", bad, page)
+		}
+	}
+	if !strings.Contains(page, "<details>&lt;img src=x onerror=alert(1)&gt;") || !strings.Contains(page, "<summary>查看全部</summary>&lt;script&gt;") {
+		t.Errorf("allowed tags should be kept and the rest of the line escaped:\n%s", page)
+	}
+}
+
+func TestRenderHTML_TableOfContents(t *testing.T) {
+	markdown := "### 前言\n\n## 最近更新\n\n### 新增资料\n\n- 条目\n\n## 待解决的 Issues\n\n### [标题 &amp; 说明](https://github.com/a/b/issues/1)\n"
+	page, err := RenderHTML(markdown, "预览")
+	if err != nil {
+		t.Fatalf("RenderHTML() returned error: %v", err)
+	}
+	for _, want := range []string{
+		"<title>预览</title>",
+		`<h3 id="section-1">前言</h3>`,
+		`<h2 id="section-2">最近更新</h2>`,
+		`<li><a href="#section-3">新增资料</a></li>`,
+		`<li><a href="#section-5">标题 &amp; 说明</a></li>`,
+		`<h3 id="section-5"><a href="https://github.com/a/b/issues/1">标题 &amp; 说明</a></h3>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %q:\n%s", want, page)
+		}
+	}
+
+	_, toc := addHeadingAnchors("<h3>前言</h3>\n<h2>A</h2>\n<h3>B</h3>\n<h2>C</h2>\n")
+	if len(toc) != 3 || len(toc[1].Children) != 1 || toc[1].Children[0].Text != "B" || len(toc[2].Children) != 0 {
+		t.Errorf("unexpected toc: %+v", toc)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "weekly-2026-02-08")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	input := filepath.Join(dir, "index.md")
+	if err := os.WriteFile(input, []byte("## 更新内容\n\n- 条目\n"), 0o644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	if err := WriteHTML(input, ""); err != nil {
+		t.Fatalf("WriteHTML() returned error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatalf("expected index.html next to the report: %v", err)
+	}
+	if !strings.Contains(string(data), "<title>weekly-2026-02-08</title>") || !strings.Contains(string(data), "<style>") {
+		t.Errorf("unexpected page:\n%s", data)
+	}
+
+	if err := WriteHTML(filepath.Join(dir, "missing.md"), ""); err == nil {
+		t.Errorf("expected error for missing input")
+	}
+}
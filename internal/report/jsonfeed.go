@@ -0,0 +1,183 @@
+// 日报的 JSON Feed 输出：每条提交、issue 和 PR 各为一个条目，跨运行累积并按保留天数清理，
+// 格式见 https://www.jsonfeed.org/version/1.1/
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// jsonFeed 是 JSON Feed 1.1 文档。
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// jsonFeedItem 是一个条目。ID 只取决于 commit SHA 或 issue/PR 地址，内容更新时保持不变。
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentText   string           `json:"content_text"`
+	DatePublished time.Time        `json:"date_published"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+// dailyFeedPath 返回与日报并列的 JSON Feed 路径，如 news/daily.md -> news/daily.feed.json。
+func dailyFeedPath(markdownPath string) string {
+	return strings.TrimSuffix(reportJSONPath(markdownPath), ".json") + ".feed.json"
+}
+
+// newDailyFeedItems 将本次收集到的提交（去重前）、issues 和 PR 转换为条目。
+func newDailyFeedItems(orgName string, commits []CommitEntry, repoNames map[string]string, issues, prs []github.Item) []jsonFeedItem {
+	items := make([]jsonFeedItem, 0, len(commits)+len(issues)+len(prs))
+	for _, commit := range commits {
+		if commit.SHA == "" {
+			continue
+		}
+		course := courseTitle(commit.RepoName, repoNames)
+		subject := strings.Split(commit.Message, "\n")[0]
+		url := fmt.Sprintf("https://github.com/%s/%s/commit/%s", orgName, commit.RepoName, commit.SHA)
+		item := jsonFeedItem{
+			ID:            url,
+			URL:           url,
+			Title:         fmt.Sprintf("%s：%s", course, subject),
+			DatePublished: commit.Date.UTC(),
+			Tags:          []string{commit.RepoName, categoryOf(commit).Title()},
+		}
+		names := make([]string, 0, 1+len(commit.CoAuthors))
+		for _, id := range commitCredits(commit) {
+			names = append(names, id.Name)
+			item.Authors = append(item.Authors, jsonFeedAuthor{Name: id.Name, URL: id.ProfileURL(), Avatar: id.Avatar})
+		}
+		item.ContentText = fmt.Sprintf("%s 在 %s 中提交了信息：%s", strings.Join(names, "、"), course, commit.Message)
+		if commit.PRURL != "" {
+			item.URL = commit.PRURL
+		}
+		items = append(items, item)
+	}
+	items = append(items, newItemFeedItems(issues, "issue", repoNames)...)
+	items = append(items, newItemFeedItems(prs, "pull request", repoNames)...)
+	return items
+}
+
+// newItemFeedItems 将 issues 或 PR 转换为条目，创建时间无法解析的跳过。
+func newItemFeedItems(list []github.Item, kind string, repoNames map[string]string) []jsonFeedItem {
+	items := make([]jsonFeedItem, 0, len(list))
+	for _, it := range list {
+		created, ok := parseCreatedAt(it.CreatedAt)
+		if !ok || it.URL == "" {
+			continue
+		}
+		course := courseTitle(it.Repository.Name, repoNames)
+		item := jsonFeedItem{
+			ID:            it.URL,
+			URL:           it.URL,
+			Title:         fmt.Sprintf("%s：%s", course, it.Title),
+			ContentText:   fmt.Sprintf("%s 在 %s 中创建了 %s：%s", it.Author.Login, course, kind, it.Title),
+			DatePublished: created.UTC(),
+			Tags:          []string{it.Repository.Name, kind},
+		}
+		if it.Author.Login != "" {
+			item.Authors = []jsonFeedAuthor{{Name: it.Author.Login, URL: "https://github.com/" + it.Author.Login}}
+		}
+		for _, label := range it.Labels {
+			item.Tags = append(item.Tags, label.Name)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// loadJSONFeed 读取已有的 JSON Feed，文件不存在时返回空的订阅源。
+func loadJSONFeed(path string) (jsonFeed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return jsonFeed{}, nil
+		}
+		return jsonFeed{}, fmt.Errorf("failed to read JSON feed %q: %w", path, err)
+	}
+	var feed jsonFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return jsonFeed{}, fmt.Errorf("failed to parse JSON feed %q: %w", path, err)
+	}
+	return feed, nil
+}
+
+// mergeFeedItems 将新条目合并进已有条目：ID 相同的以新条目为准，
+// 发布时间早于 cutoff 的条目被清理，结果按发布时间降序、ID 升序排列。
+func mergeFeedItems(existing, fresh []jsonFeedItem, cutoff time.Time) []jsonFeedItem {
+	byID := make(map[string]jsonFeedItem, len(existing)+len(fresh))
+	for _, item := range existing {
+		byID[item.ID] = item
+	}
+	for _, item := range fresh {
+		byID[item.ID] = item
+	}
+	items := make([]jsonFeedItem, 0, len(byID))
+	for _, item := range byID {
+		if item.DatePublished.Before(cutoff) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DatePublished.Equal(items[j].DatePublished) {
+			return items[i].DatePublished.After(items[j].DatePublished)
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
+
+// updateDailyFeed 将本次的条目合并进 path 处的 JSON Feed 并写回，只保留最近 days 天内发布的条目。
+// 输出不含生成时间，条目没有变化时文件内容不变。
+func updateDailyFeed(path, siteURL string, items []jsonFeedItem, days int, now time.Time) error {
+	existing, err := loadJSONFeed(path)
+	if err != nil {
+		return err
+	}
+	site := strings.TrimSuffix(siteURL, "/")
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       "AUTO 更新速递",
+		HomePageURL: site + "/news/daily/",
+		FeedURL:     site + "/news/daily.feed.json",
+		Description: "HITSZ-OpenAuto 各课程仓库的每条提交、issue 和 pull request",
+		Language:    "zh-CN",
+		Authors:     []jsonFeedAuthor{{Name: "github-actions[bot]", URL: "https://github.com/features/actions"}},
+		Items:       mergeFeedItems(existing.Items, items, now.AddDate(0, 0, -days)),
+	}
+	data, err := json.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON feed: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write JSON feed %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+func TestNewDailyFeedItems(t *testing.T) {
+	commits := []CommitEntry{
+		{
+			AuthorName: "张三", AuthorLogin: "zhangsan", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, utils.BeijingTimeZone),
+			Message: "上传期末试卷\n\n补充说明", RepoName: "EE3001", SHA: "abc123",
+			PRNumber: 7, PRURL: "https://github.com/HITSZ-OpenAuto/EE3001/pull/7",
+			CoAuthors: []identity.Identity{{Name: "李四"}},
+		},
+		{AuthorName: "王五", Message: "无 SHA 的提交", RepoName: "EE3001"},
+	}
+	issues := []github.Item{
+		{Title: "缺少试卷", URL: "https://github.com/HITSZ-OpenAuto/EE3001/issues/3", CreatedAt: "2026-02-09T08:00:00Z",
+			Repository: github.Repository{Name: "EE3001"}, Author: github.Author{Login: "reader"}, Labels: []github.Label{{Name: "help wanted"}}},
+		{Title: "时间无效", URL: "https://github.com/HITSZ-OpenAuto/EE3001/issues/4", CreatedAt: "yesterday"},
+	}
+	items := newDailyFeedItems("HITSZ-OpenAuto", commits, map[string]string{"EE3001": "电路原理"}, issues, nil)
+	if len(items) != 2 {
+		t.Fatalf("expected commit and issue items, got %+v", items)
+	}
+
+	commit := items[0]
+	if commit.ID != "https://github.com/HITSZ-OpenAuto/EE3001/commit/abc123" || commit.URL != "https://github.com/HITSZ-OpenAuto/EE3001/pull/7" {
+		t.Errorf("unexpected commit id/url: %q %q", commit.ID, commit.URL)
+	}
+	if commit.Title != "电路原理：上传期末试卷" || commit.ContentText != "张三、李四 在 电路原理 中提交了信息：上传期末试卷\n\n补充说明" {
+		t.Errorf("unexpected commit text: %q / %q", commit.Title, commit.ContentText)
+	}
+	if !commit.DatePublished.Equal(time.Date(2026, 2, 10, 2, 0, 0, 0, time.UTC)) || commit.DatePublished.Location() != time.UTC {
+		t.Errorf("DatePublished = %v, want UTC", commit.DatePublished)
+	}
+	if len(commit.Authors) != 2 || commit.Authors[0].URL != "https://github.com/zhangsan" {
+		t.Errorf("unexpected authors: %+v", commit.Authors)
+	}
+
+	issue := items[1]
+	if issue.ID != issues[0].URL || len(issue.Tags) != 3 || issue.Tags[2] != "help wanted" {
+		t.Errorf("unexpected issue item: %+v", issue)
+	}
+}
+
+func TestUpdateDailyFeed_MergesAndPrunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.feed.json")
+	now := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	item := func(id string, daysAgo int, title string) jsonFeedItem {
+		return jsonFeedItem{ID: id, Title: title, ContentText: title, DatePublished: now.AddDate(0, 0, -daysAgo)}
+	}
+
+	if err := updateDailyFeed(path, "https://hoa.moe/", []jsonFeedItem{item("a", 1, "旧标题"), item("old", 6, "较早")}, 7, now); err != nil {
+		t.Fatalf("updateDailyFeed() returned error: %v", err)
+	}
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read feed: %v", err)
+	}
+
+	// 再次运行相同条目时输出不变
+	if err := updateDailyFeed(path, "https://hoa.moe/", []jsonFeedItem{item("a", 1, "旧标题")}, 7, now); err != nil {
+		t.Fatalf("updateDailyFeed() returned error: %v", err)
+	}
+	if again, _ := os.ReadFile(path); string(again) != string(first) {
+		t.Errorf("feed changed without new items:\n%s\nwant\n%s", again, first)
+	}
+
+	// 两天后：新增条目、同 ID 条目以新内容为准、过期条目被清理
+	later := now.AddDate(0, 0, 2)
+	if err := updateDailyFeed(path, "https://hoa.moe/", []jsonFeedItem{item("b", -2, "新条目"), item("a", 1, "新标题")}, 7, later); err != nil {
+		t.Fatalf("updateDailyFeed() returned error: %v", err)
+	}
+	feed, err := loadJSONFeed(path)
+	if err != nil {
+		t.Fatalf("loadJSONFeed() returned error: %v", err)
+	}
+	if feed.Version != jsonFeedVersion || feed.Title == "" || feed.FeedURL != "https://hoa.moe/news/daily.feed.json" {
+		t.Errorf("unexpected feed header: %+v", feed)
+	}
+	if len(feed.Items) != 2 || feed.Items[0].ID != "b" || feed.Items[1].ID != "a" || feed.Items[1].Title != "新标题" {
+		t.Errorf("unexpected items: %+v", feed.Items)
+	}
+}
+
+func TestDailyFeed_SpecFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.feed.json")
+	items := newDailyFeedItems("HITSZ-OpenAuto", []CommitEntry{{AuthorName: "张三", Message: "更新", RepoName: "A", SHA: "1", Date: time.Now()}}, nil, nil, nil)
+	if err := updateDailyFeed(path, "https://hoa.moe", items, 7, time.Now()); err != nil {
+		t.Fatalf("updateDailyFeed() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read feed: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("feed is not valid JSON: %v", err)
+	}
+	// JSON Feed 1.1 的必填字段：version、title、items，条目需要 id 以及 content_html 或 content_text
+	if raw["version"] != jsonFeedVersion || raw["title"] == "" {
+		t.Errorf("missing required top-level fields: %v", raw)
+	}
+	list, ok := raw["items"].([]any)
+	if !ok || len(list) != 1 {
+		t.Fatalf("items should be a one-element array, got %v", raw["items"])
+	}
+	entry := list[0].(map[string]any)
+	if id, _ := entry["id"].(string); id == "" {
+		t.Errorf("item id is required: %v", entry)
+	}
+	if text, _ := entry["content_text"].(string); text == "" {
+		t.Errorf("item needs content_text: %v", entry)
+	}
+	if published, _ := entry["date_published"].(string); published == "" {
+		t.Errorf("item date_published missing: %v", entry)
+	} else if _, err := time.Parse(time.RFC3339, published); err != nil {
+		t.Errorf("date_published is not RFC 3339: %q", published)
+	}
+	if got := dailyFeedPath("news/daily.md"); got != "news/daily.feed.json" {
+		t.Errorf("dailyFeedPath() = %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+// 已生成的报告：读取最新的日报或周报，供邮件等投递渠道使用
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+)
+
+const dailyPath = "news/daily.md"
+
+// Published 是一份已生成的报告，Body 为去掉 front matter 后的 Markdown 正文。
+type Published struct {
+	Kind        string // ReportKindDaily 或 ReportKindWeekly
+	Title       string
+	Description string
+	Date        string // front matter 中的日期，原样保留
+	URL         string // 报告在网站上的地址
+	Body        string
+	Summary     string          // 周报的 AI 摘要正文，不含标题，没有摘要时为空
+	Document    *ReportDocument // 与报告并列的 JSON 输出，未开启 output.json 时为 nil
+}
+
+// LoadPublished 读取最新一份 kind 类型的报告：日报为 news/daily.md，周报为 news/weekly 下日期最新的一期。
+func LoadPublished(kind string, cfg *config.Config) (Published, error) {
+	return loadPublished(kind, dailyPath, weeklyRoot, cfg.Feed.SiteURL)
+}
+
+func loadPublished(kind, dailyFile, weeklyDir, siteURL string) (Published, error) {
+	switch kind {
+	case ReportKindDaily:
+		data, err := os.ReadFile(dailyFile)
+		if err != nil {
+			return Published{}, fmt.Errorf("failed to read daily report %q: %w", dailyFile, err)
+		}
+		fm, body, _, err := splitFrontMatter(string(data))
+		if err != nil {
+			return Published{}, fmt.Errorf("failed to parse daily report %q: %w", dailyFile, err)
+		}
+		doc, err := readReportJSON(reportJSONPath(dailyFile))
+		if err != nil {
+			return Published{}, err
+		}
+		return Published{
+			Kind:        kind,
+			Title:       strings.TrimSpace(fm.Title),
+			Description: strings.TrimSpace(fm.Description),
+			Date:        strings.TrimSpace(fm.Date),
+			URL:         strings.TrimSuffix(siteURL, "/") + "/news/daily/",
+			Body:        strings.TrimSpace(body),
+			Document:    doc,
+		}, nil
+	case ReportKindWeekly:
+		posts, err := loadFeedPosts(weeklyDir)
+		if err != nil {
+			return Published{}, err
+		}
+		if len(posts) == 0 {
+			return Published{}, fmt.Errorf("no weekly report found in %q", weeklyDir)
+		}
+		post := posts[0]
+		doc, err := readReportJSON(filepath.Join(weeklyDir, post.Slug, "index.json"))
+		if err != nil {
+			return Published{}, err
+		}
+		return Published{
+			Kind:        kind,
+			Title:       post.Title,
+			Description: post.Description,
+			Date:        post.Date.Format("2006-01-02"),
+			URL:         postURL(siteURL, post.Slug),
+			Body:        post.Body,
+			Summary:     summarySection(post.Body),
+			Document:    doc,
+		}, nil
+	default:
+		return Published{}, fmt.Errorf("unknown report kind %q", kind)
+	}
+}
+
+// HTML 将报告渲染为独立的 HTML 页面，与 html 命令的输出相同。
+func (p Published) HTML() (string, error) {
+	return renderHTMLPage(p.Title, p.Description, p.Date, p.Body)
+}
+
+// SameAs 判断本报告与上次投递的正文 previous 是否实质相同，规则与日报是否需要重写相同。
+func (p Published) SameAs(previous string) bool {
+	return isSubstantivelyEqual(previous, p.Body)
+}
+
+// readReportJSON 读取报告的 JSON 输出，文件不存在时返回 nil。
+func readReportJSON(path string) (*ReportDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read report JSON %q: %w", path, err)
+	}
+	var doc ReportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse report JSON %q: %w", path, err)
+	}
+	return &doc, nil
+}
@@ -0,0 +1,43 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPublished(t *testing.T) {
+	dir := setupWeeklyArchive(t)
+	weekly, err := loadPublished(ReportKindWeekly, "", dir, "https://hoa.moe/")
+	if err != nil {
+		t.Fatalf("loadPublished(weekly) returned error: %v", err)
+	}
+	if weekly.Title != "AUTO 周报 2026-02-08 - 2026-02-15" || weekly.Date != "2026-02-15" || weekly.URL != "https://hoa.moe/news/weekly/weekly-2026-02-08/" {
+		t.Errorf("weekly = %+v, want the latest non-draft report", weekly)
+	}
+
+	dailyFile := filepath.Join(t.TempDir(), "daily.md")
+	content := "---\ntitle: AUTO 更新速递\ndate: 2026-02-10\ndescription: 每日更新\n---\n\n## 最近更新\n\n- 张三 在 [电路原理](https://github.com/HITSZ-OpenAuto/EE3001) 中提交了信息：上传试卷\n"
+	if err := os.WriteFile(dailyFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write daily report: %v", err)
+	}
+	daily, err := loadPublished(ReportKindDaily, dailyFile, dir, "https://hoa.moe")
+	if err != nil {
+		t.Fatalf("loadPublished(daily) returned error: %v", err)
+	}
+	if daily.Title != "AUTO 更新速递" || daily.URL != "https://hoa.moe/news/daily/" || !strings.HasPrefix(daily.Body, "## 最近更新") {
+		t.Errorf("daily = %+v", daily)
+	}
+	page, err := daily.HTML()
+	if err != nil {
+		t.Fatalf("HTML() returned error: %v", err)
+	}
+	if !strings.Contains(page, "<title>AUTO 更新速递</title>") || !strings.Contains(page, "上传试卷") {
+		t.Errorf("HTML page missing title or body:\n%s", page)
+	}
+
+	if _, err := loadPublished("monthly", dailyFile, dir, ""); err == nil {
+		t.Errorf("expected error for unknown kind")
+	}
+}
@@ -0,0 +1,170 @@
+// 报告的 JSON 输出：与 Markdown 并列写出结构化数据，供网站小组件和其他工具使用，
+// 结构定义见仓库根目录下的 schema/report.schema.json
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+)
+
+// ReportSchemaVersion 是 JSON 输出的结构版本，字段发生不兼容变化时递增，并同步更新 schema 文件。
+const ReportSchemaVersion = 1
+
+// 报告类型。
+const (
+	ReportKindDaily  = "daily"
+	ReportKindWeekly = "weekly"
+)
+
+// ReportDocument 是一份报告的 JSON 输出。
+type ReportDocument struct {
+	Version      int                   `json:"version"`      // 即 ReportSchemaVersion
+	Kind         string                `json:"kind"`         // daily 或 weekly
+	Org          string                `json:"org"`          // GitHub 组织名
+	GeneratedAt  time.Time             `json:"generated_at"` // 生成时间
+	Window       ReportWindow          `json:"window"`       // 提交的统计时间窗口
+	Commits      []ReportCommit        `json:"commits"`      // 过滤后、去重前的提交，按时间降序
+	Issues       []ReportItem          `json:"issues"`       // 待解决的 issues，周报为空
+	PullRequests []ReportItem          `json:"pull_requests"`
+	Courses      map[string]CourseMeta `json:"courses"`   // repo 名 -> 课程元数据，仅包含有提交的课程
+	Automated    int                   `json:"automated"` // 被识别为 bot 或自动化操作的提交数
+}
+
+// ReportWindow 是报告的时间窗口，左闭右开。
+type ReportWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ReportPerson 是提交作者或共同作者。
+type ReportPerson struct {
+	Name   string `json:"name"`
+	Login  string `json:"login,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// ReportPullRequest 是提交关联的 PR。
+type ReportPullRequest struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// ReportCommit 是一条提交。
+type ReportCommit struct {
+	SHA         string             `json:"sha"`
+	URL         string             `json:"url"`
+	Repo        string             `json:"repo"`
+	Date        time.Time          `json:"date"`
+	Message     string             `json:"message"`  // 规范化后的提交信息
+	Category    string             `json:"category"` // 类别标识，如 material、correction
+	Author      ReportPerson       `json:"author"`
+	CoAuthors   []ReportPerson     `json:"co_authors,omitempty"`
+	PullRequest *ReportPullRequest `json:"pull_request,omitempty"`
+	Files       []string           `json:"files,omitempty"` // 仅在开启 categories.fetch_files 时存在
+}
+
+// ReportItem 是一条 issue 或 pull request。
+type ReportItem struct {
+	Title     string   `json:"title"`
+	URL       string   `json:"url"`
+	Repo      string   `json:"repo"`
+	CreatedAt string   `json:"created_at"` // GitHub 返回的 RFC3339 时间
+	Author    string   `json:"author"`
+	Labels    []string `json:"labels,omitempty"`
+}
+
+// newReportDocument 构建报告的 JSON 输出。commits 应为去重前的提交，函数内部会排序。
+func newReportDocument(kind, orgName string, window ReportWindow, commits []CommitEntry, courses map[string]CourseMeta, issues, prs []github.Item, automated int) ReportDocument {
+	sorted := append([]CommitEntry(nil), commits...)
+	sortCommits(sorted)
+
+	doc := ReportDocument{
+		Version:      ReportSchemaVersion,
+		Kind:         kind,
+		Org:          orgName,
+		GeneratedAt:  time.Now().UTC().Truncate(time.Second),
+		Window:       window,
+		Commits:      make([]ReportCommit, 0, len(sorted)),
+		Issues:       newReportItems(issues),
+		PullRequests: newReportItems(prs),
+		Courses:      make(map[string]CourseMeta, len(courses)),
+		Automated:    automated,
+	}
+	for _, commit := range sorted {
+		doc.Commits = append(doc.Commits, newReportCommit(commit))
+	}
+	for repo, meta := range courses {
+		doc.Courses[repo] = meta
+	}
+	return doc
+}
+
+func newReportCommit(commit CommitEntry) ReportCommit {
+	c := ReportCommit{
+		SHA:      commit.SHA,
+		URL:      commit.URL,
+		Repo:     commit.RepoName,
+		Date:     commit.Date,
+		Message:  commit.Message,
+		Category: string(categoryOf(commit)),
+		Author:   ReportPerson{Name: commit.AuthorName, Login: commit.AuthorLogin, Avatar: commit.AuthorAvatar},
+		Files:    commit.Files,
+	}
+	for _, co := range commit.CoAuthors {
+		c.CoAuthors = append(c.CoAuthors, ReportPerson{Name: co.Name, Login: co.Login, Avatar: co.Avatar})
+	}
+	if commit.PRNumber > 0 {
+		c.PullRequest = &ReportPullRequest{Number: commit.PRNumber, URL: commit.PRURL}
+	}
+	return c
+}
+
+func newReportItems(items []github.Item) []ReportItem {
+	result := make([]ReportItem, 0, len(items))
+	for _, item := range items {
+		r := ReportItem{
+			Title:     item.Title,
+			URL:       item.URL,
+			Repo:      item.Repository.Name,
+			CreatedAt: item.CreatedAt,
+			Author:    item.Author.Login,
+		}
+		for _, label := range item.Labels {
+			r.Labels = append(r.Labels, label.Name)
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// reportJSONPath 返回与 Markdown 文件并列的 JSON 文件路径，如 news/daily.md -> news/daily.json。
+func reportJSONPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + ".json"
+}
+
+// keepCommits 沿用 old 的时间窗口、提交、课程元数据和自动化操作数，
+// 与日报在没有新提交时保留上一次「最近更新」段落的做法一致。
+func (d *ReportDocument) keepCommits(old ReportDocument) {
+	d.Window = old.Window
+	d.Commits = old.Commits
+	d.Courses = old.Courses
+	d.Automated = old.Automated
+}
+
+// writeReportJSON 将报告写入 path。
+func writeReportJSON(path string, doc ReportDocument) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report JSON: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write report JSON %q: %w", path, err)
+	}
+	return nil
+}
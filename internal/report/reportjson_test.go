@@ -0,0 +1,214 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
+)
+
+const reportSchemaPath = "../../schema/report.schema.json"
+
+func sampleReportDocument() ReportDocument {
+	commits := []CommitEntry{
+		{
+			AuthorName: "张三", AuthorLogin: "zhangsan", Date: time.Date(2026, 2, 10, 2, 0, 0, 0, time.UTC),
+			Message: "上传期末试卷", RepoName: "EE3001", SHA: "abc123", URL: "https://github.com/HITSZ-OpenAuto/EE3001/commit/abc123",
+			PRNumber: 12, PRURL: "https://github.com/HITSZ-OpenAuto/EE3001/pull/12",
+			CoAuthors: []identity.Identity{{Name: "李四", Login: "lisi"}},
+			Files:     []string{"exams/2025.pdf"},
+		},
+		{AuthorName: "王五", Date: time.Date(2026, 2, 10, 5, 0, 0, 0, time.UTC), Message: "修正答案错误", RepoName: "COMP3052", SHA: "def456"},
+	}
+	courses := map[string]CourseMeta{
+		"EE3001": {Name: "电路原理", Code: "EE3001", Credits: 3.5, Hours: 56, Teachers: []Teacher{{Name: "赵老师"}}},
+	}
+	issues := []github.Item{{
+		Title: "缺少 2024 年试卷", URL: "https://github.com/HITSZ-OpenAuto/EE3001/issues/3", Repository: github.Repository{Name: "EE3001"},
+		CreatedAt: "2026-02-09T08:00:00Z", Author: github.Author{Login: "reader"}, Labels: []github.Label{{Name: "help wanted"}},
+	}}
+	window := ReportWindow{Start: time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)}
+	return newReportDocument(ReportKindDaily, "HITSZ-OpenAuto", window, commits, courses, issues, nil, 4)
+}
+
+func TestReportDocument_RoundTrip(t *testing.T) {
+	doc := sampleReportDocument()
+	if doc.Commits[0].SHA != "def456" {
+		t.Errorf("commits should be sorted newest first, got %+v", doc.Commits)
+	}
+	if doc.Commits[0].Category != string(categorize.Correction) || doc.Commits[1].PullRequest.Number != 12 {
+		t.Errorf("unexpected commits: %+v", doc.Commits)
+	}
+
+	path := filepath.Join(t.TempDir(), "daily.json")
+	if err := writeReportJSON(path, doc); err != nil {
+		t.Fatalf("writeReportJSON() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read JSON: %v", err)
+	}
+	var decoded ReportDocument
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, doc) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", decoded, doc)
+	}
+}
+
+func TestReportDocument_MatchesSchema(t *testing.T) {
+	schema := loadSchema(t)
+	for _, doc := range []ReportDocument{
+		sampleReportDocument(),
+		newReportDocument(ReportKindWeekly, "HITSZ-OpenAuto", ReportWindow{}, nil, nil, nil, nil, 0),
+	} {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("json.Marshal() returned error: %v", err)
+		}
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			t.Fatalf("json.Unmarshal() returned error: %v", err)
+		}
+		if errs := validateSchema(schema, schema, value, "$"); len(errs) > 0 {
+			t.Errorf("%s document does not match schema:\n%s", doc.Kind, strings.Join(errs, "\n"))
+		}
+	}
+
+	// schema 中的版本和类别需要与代码保持一致
+	props := schema["properties"].(map[string]any)
+	if v := props["version"].(map[string]any)["const"]; v != float64(ReportSchemaVersion) {
+		t.Errorf("schema version = %v, want %d", v, ReportSchemaVersion)
+	}
+	commit := schema["$defs"].(map[string]any)["commit"].(map[string]any)["properties"].(map[string]any)
+	var enum []string
+	for _, c := range commit["category"].(map[string]any)["enum"].([]any) {
+		enum = append(enum, c.(string))
+	}
+	var want []string
+	for _, c := range categorize.Order {
+		want = append(want, string(c))
+	}
+	sort.Strings(enum)
+	sort.Strings(want)
+	if !reflect.DeepEqual(enum, want) {
+		t.Errorf("schema categories = %v, want %v", enum, want)
+	}
+}
+
+func TestReportJSONPath(t *testing.T) {
+	if got := reportJSONPath("news/daily.md"); got != "news/daily.json" {
+		t.Errorf("reportJSONPath() = %q", got)
+	}
+	if got := reportJSONPath("news/weekly/weekly-2026-02-08/index.md"); got != "news/weekly/weekly-2026-02-08/index.json" {
+		t.Errorf("reportJSONPath() = %q", got)
+	}
+}
+
+func loadSchema(t *testing.T) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(reportSchemaPath)
+	if err != nil {
+		t.Fatalf("failed to read schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return schema
+}
+
+// validateSchema 按 schema 中用到的关键字（type、const、enum、minimum、required、properties、
+// additionalProperties、items、$ref）校验 value，返回全部不匹配之处。
+func validateSchema(root, schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")]
+		return validateSchema(root, def.(map[string]any), value, path)
+	}
+	var errs []string
+	fail := func(format string, args ...any) {
+		errs = append(errs, path+": "+fmt.Sprintf(format, args...))
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		fail("got %v, want %v", value, c)
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			found = found || reflect.DeepEqual(e, value)
+		}
+		if !found {
+			fail("%v is not one of %v", value, enum)
+		}
+	}
+	if min, ok := schema["minimum"].(float64); ok {
+		if n, isNum := value.(float64); isNum && n < min {
+			fail("%v is less than %v", n, min)
+		}
+	}
+
+	switch schema["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			fail("expected string, got %T", value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			fail("expected number, got %T", value)
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			fail("expected integer, got %v", value)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %T", value)
+			break
+		}
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for i, item := range items {
+				errs = append(errs, validateSchema(root, itemSchema, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %T", value)
+			break
+		}
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := obj[key.(string)]; !ok {
+				fail("missing required property %q", key)
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for key, v := range obj {
+			if prop, ok := props[key].(map[string]any); ok {
+				errs = append(errs, validateSchema(root, prop, v, path+"."+key)...)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					fail("unexpected property %q", key)
+				}
+			case map[string]any:
+				errs = append(errs, validateSchema(root, extra, v, path+"."+key)...)
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,276 @@
+// 基于 text/template 的日报和周报渲染。内置模板随二进制嵌入，
+// 配置 templates_dir 后，目录中的同名模板文件会覆盖内置模板。
+package report
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// 可覆盖的模板文件名。
+const (
+	dailyTemplate  = "daily.md.tmpl"  // 日报正文，数据为 DailyData
+	weeklyTemplate = "weekly.md.tmpl" // 周报正文（front matter 之后），数据为 WeeklyData
+)
+
+// 模板文件中 define 的子模板。
+const (
+	dailyItemsTemplate    = "daily-items"    // Issues 或 Pull Requests 列表，数据为 []github.Item，课程动态页复用
+	weeklyUpdatesTemplate = "weekly-updates" // 周报「更新内容」段落，AI 摘要以它为输入
+)
+
+// 模板数据模型。注释中标注为 Markdown 的字段已经转义并渲染好链接，可直接输出；
+// 其余字符串字段为原始文本，输出前应使用 inline、label 等转义函数（见 utils.TemplateFuncs）。
+
+// CommitView 是模板中的一条提交。
+type CommitView struct {
+	Entry    CommitEntry // 原始提交数据
+	Author   string      // Markdown：作者及共同作者，有 GitHub 登录名时链接到个人主页
+	Location string      // Markdown：提交所在课程的链接，批量提交时列出前几门课程和课程总数
+	Details  string      // Markdown：批量提交涉及课程过多时列出全部课程的 <details> 块，否则为空
+	Message  string      // Markdown：提交信息首行，附 PR 和 commit 链接
+	Batch    string      // 批量提交的次数后缀，如「（共 3 次提交）」，非批量提交为空
+	Weekday  string      // 提交日期是周几，如「周二」或 Tue
+	Date     string      // 提交日期，如 2.10 或 Feb 10
+	Time     string      // 提交时间，如 15:04
+	Course   CourseMeta  // 提交所在课程的元数据，批量提交时为首个仓库的元数据，未能获取时为零值
+}
+
+// CategoryView 是同一类别下的提交。日常维护类（Trivial 为 true）只提供 Total 和 Courses。
+type CategoryView struct {
+	Heading string       // 类别标题的 Markdown 标题前缀，如 ###
+	Title   string       // 类别名，如「新增资料」
+	Trivial bool         // 是否为折叠展示的日常维护类
+	Commits []CommitView // 非日常维护类的提交，按渲染顺序排列
+	Total   int          // 含去重合并的提交数
+	Courses []string     // Markdown：涉及课程的链接，按仓库名排序
+}
+
+// CourseView 是一门课程下的提交。
+type CourseView struct {
+	Heading    string     // 课程标题的 Markdown 标题前缀，类别标题比它多一级
+	Title      string     // Markdown：课程名，已按链接文本转义
+	URL        string     // 课程仓库地址
+	Count      int        // 含去重合并的提交数
+	Course     CourseMeta // 课程元数据，未能获取时为零值
+	Categories []CategoryView
+}
+
+// DepartmentView 是一个院系下的提交。周报使用 Courses，日报使用 Categories。
+type DepartmentView struct {
+	Title      string // Markdown：院系名
+	Count      int    // 含去重合并的提交数
+	Courses    []CourseView
+	Categories []CategoryView
+}
+
+// DayView 是某一天的提交。
+type DayView struct {
+	Weekday    string // 周几，如「周二」或 Tue
+	Date       string // 日期，如 2.10 或 Feb 10
+	Categories []CategoryView
+}
+
+// DailyData 是日报模板的数据。按院系分组时 Departments 非空，否则使用 Categories。
+type DailyData struct {
+	Org         string
+	Empty       bool             // 时间窗口内没有提交
+	Categories  []CategoryView   // 按类别分组的提交
+	Departments []DepartmentView // 按院系分组的提交
+	Batches     []CategoryView   // 按院系分组时，涉及多门课程的批量提交
+	Automated   int              // 未列出的自动化操作提交数
+	Issues      []github.Item    // 待解决的 issues，原始数据
+	PRs         []github.Item    // 待合并的 pull requests，原始数据
+}
+
+// FirstTimerView 是一位本周完成第一次贡献的新贡献者。
+type FirstTimerView struct {
+	Author   string     // Markdown：作者及共同作者
+	Location string     // Markdown：第一次贡献所在课程的链接
+	Message  string     // Markdown：第一次贡献的提交信息首行
+	Date     string     // 第一次贡献的日期，如 2.10 或 Feb 10
+	Total    int        // 本周的提交总数
+	Course   CourseMeta // 第一次贡献所在课程的元数据，未能获取时为零值
+}
+
+// StatsView 是「本周统计」的数据。
+type StatsView struct {
+	Commits      int // 提交数
+	Contributors int // 贡献者数
+	Courses      int // 涉及课程数
+	FirstTimers  int // 新贡献者数
+	Automated    int // 自动化操作提交数
+}
+
+// WeeklyData 是周报正文模板的数据，Layout 决定「更新内容」使用哪个分组字段。
+type WeeklyData struct {
+	Org         string
+	Summary     string           // Markdown：AI 摘要段落，含标题，为空时不输出
+	FirstTimers []FirstTimerView // 新贡献者，按第一次贡献的时间排列
+	Stats       StatsView
+	Empty       bool             // 时间窗口内没有提交
+	Layout      string           // day、course 或 department
+	Days        []DayView        // day 布局，按日期降序
+	Courses     []CourseView     // course 布局，按提交数降序
+	Departments []DepartmentView // department 布局，按提交数降序
+	Batches     []CommitView     // course 和 department 布局下涉及多门课程的批量提交
+}
+
+// renderer 持有解析好的报告模板及其输出语言。
+type renderer struct {
+	tmpl *template.Template
+	lang i18n.Lang
+}
+
+// defaultRenderer 只使用内置模板，输出默认语言。
+var defaultRenderer = func() *renderer {
+	r, err := newRenderer("", i18n.Default)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}()
+
+// newRenderer 解析内置模板，dir 非空时用其中存在的同名模板文件覆盖内置模板。
+// 覆盖文件中 define 的子模板同样会替换内置的同名子模板。
+// 模板中的 t、weekday 函数按 lang 输出，lang 函数返回语言代码，供覆盖模板按语言分支。
+func newRenderer(dir string, lang i18n.Lang) (*renderer, error) {
+	funcs := utils.TemplateFuncs()
+	funcs["t"] = lang.T
+	funcs["weekday"] = lang.Weekday
+	funcs["lang"] = func() string { return string(lang) }
+	tmpl, err := template.New("").Funcs(funcs).ParseFS(builtinTemplates, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse built-in templates: %w", err)
+	}
+	if dir == "" {
+		return &renderer{tmpl: tmpl, lang: lang}, nil
+	}
+	for _, name := range []string{dailyTemplate, weeklyTemplate} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read template %q: %w", path, err)
+		}
+		if _, err := tmpl.ParseFiles(path); err != nil {
+			return nil, fmt.Errorf("failed to parse template %q: %w", path, err)
+		}
+	}
+	return &renderer{tmpl: tmpl, lang: lang}, nil
+}
+
+// execute 使用名为 name 的模板渲染 data。
+func (r *renderer) execute(name string, data any) (string, error) {
+	var b strings.Builder
+	if err := r.tmpl.ExecuteTemplate(&b, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// mustExecute 使用内置模板渲染，内置模板出错属于程序错误。
+func (r *renderer) mustExecute(name string, data any) string {
+	out, err := r.execute(name, data)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// newCommitView 将提交转换为 lang 语言的模板数据。
+func newCommitView(lang i18n.Lang, commit CommitEntry, repoTitles map[string]string, orgName string) CommitView {
+	location, details := renderCommitLocation(lang, commit, repoTitles, orgName)
+	return CommitView{
+		Entry:    commit,
+		Author:   renderAuthor(lang, commit),
+		Location: location,
+		Details:  details,
+		Message:  renderMessage(commit), // commit message 可能有多行补充信息，只取第一行作为摘要
+		Batch:    batchSuffix(lang, commit),
+		Weekday:  lang.Weekday(commit.Date),
+		Date:     lang.ShortDate(commit.Date),
+		Time:     commit.Date.Format("15:04"),
+		Course:   commit.Course,
+	}
+}
+
+// newCategoryViews 按类别分组，heading 为类别标题的 Markdown 标题前缀。
+func newCategoryViews(lang i18n.Lang, heading string, commits []CommitEntry, repoTitles map[string]string, orgName string) []CategoryView {
+	groups := groupByCategory(commits)
+	views := make([]CategoryView, 0, len(groups))
+	for _, group := range groups {
+		view := CategoryView{
+			Heading: heading,
+			Title:   categoryTitle(lang, group.Category),
+			Trivial: group.Category == categorize.Trivial,
+		}
+		for _, commit := range group.Commits {
+			view.Total += batchSize(commit)
+		}
+		if view.Trivial {
+			view.Courses = trivialCourseLinks(group.Commits, repoTitles, orgName)
+		} else {
+			view.Commits = make([]CommitView, 0, len(group.Commits))
+			for _, commit := range group.Commits {
+				view.Commits = append(view.Commits, newCommitView(lang, commit, repoTitles, orgName))
+			}
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// newCourseView 将一门课程的提交按日期降序排列并按类别分组。
+func newCourseView(lang i18n.Lang, heading, repo string, repoCommits []CommitEntry, count int, repoTitles map[string]string, orgName string) CourseView {
+	sort.SliceStable(repoCommits, func(i, j int) bool {
+		return repoCommits[i].Date.After(repoCommits[j].Date)
+	})
+	return CourseView{
+		Heading:    heading,
+		Title:      utils.SanitizeLinkLabel(courseTitle(repo, repoTitles)),
+		URL:        fmt.Sprintf("https://github.com/%s/%s", orgName, repo),
+		Count:      count,
+		Course:     repoCommits[0].Course,
+		Categories: newCategoryViews(lang, heading+"#", repoCommits, repoTitles, orgName),
+	}
+}
+
+// newCourseViews 按仓库分组，课程按提交数降序、课程名升序排列。
+func newCourseViews(lang i18n.Lang, heading string, commits []CommitEntry, repoTitles map[string]string, orgName string) []CourseView {
+	byRepo, counts := groupByRepo(commits)
+	repos := sortedRepos(counts, repoTitles)
+	views := make([]CourseView, 0, len(repos))
+	for _, repo := range repos {
+		views = append(views, newCourseView(lang, heading, repo, byRepo[repo], counts[repo], repoTitles, orgName))
+	}
+	return views
+}
+
+// newBatchViews 将跨课程批量提交按日期降序排列。
+func newBatchViews(lang i18n.Lang, batches []CommitEntry, repoTitles map[string]string, orgName string) []CommitView {
+	sort.SliceStable(batches, func(i, j int) bool {
+		return batches[i].Date.After(batches[j].Date)
+	})
+	views := make([]CommitView, 0, len(batches))
+	for _, commit := range batches {
+		views = append(views, newCommitView(lang, commit, repoTitles, orgName))
+	}
+	return views
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+)
+
+func TestNewRenderer_Overrides(t *testing.T) {
+	dir := t.TempDir()
+	daily := "## 今日更新\n\n{{range .Categories}}{{range .Commits}}* {{inline .Entry.AuthorName}}：{{.Message}}\n{{end}}{{end}}"
+	if err := os.WriteFile(filepath.Join(dir, dailyTemplate), []byte(daily), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	// 只重新定义子模板时，保留内置的顶层模板
+	weekly := `{{define "weekly-categories"}}{{range .}}{{.Title}}: {{len .Commits}}` + "\n" + `{{end}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, weeklyTemplate), []byte(weekly), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	r, err := newRenderer(dir, i18n.Default)
+	if err != nil {
+		t.Fatalf("newRenderer() returned error: %v", err)
+	}
+	commits := []CommitEntry{
+		{AuthorName: "张<三>", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "EE3001"},
+	}
+
+	body, err := buildDailyBody(r, "HITSZ-OpenAuto", commits, nil, nil, nil, nil, 0)
+	if err != nil {
+		t.Fatalf("buildDailyBody() returned error: %v", err)
+	}
+	if want := "## 今日更新\n\n* 张&lt;三&gt;：上传期末试卷\n"; body != want {
+		t.Errorf("daily body = %q, want %q", body, want)
+	}
+
+	section, err := buildUpdatesSection(r, "day", commits, nil, nil, "HITSZ-OpenAuto")
+	if err != nil {
+		t.Fatalf("buildUpdatesSection() returned error: %v", err)
+	}
+	if want := "## 更新内容\n\n### 周二 (2.10)\n\n新增资料: 1\n"; section != want {
+		t.Errorf("weekly section = %q, want %q", section, want)
+	}
+
+	// 内置渲染不受影响
+	if got := BuildMarkdown(commits, nil, "HITSZ-OpenAuto"); !strings.Contains(got, "中提交了信息：上传期末试卷") {
+		t.Errorf("default renderer should keep built-in templates, got:\n%s", got)
+	}
+}
+
+func TestNewRenderer_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := newRenderer(filepath.Join(dir, "missing"), i18n.Default); err != nil {
+		t.Errorf("missing override directory should fall back to built-ins, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, dailyTemplate), []byte("{{range .Categories}"), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	if _, err := newRenderer(dir, i18n.Default); err == nil || !strings.Contains(err.Error(), dailyTemplate) {
+		t.Errorf("expected parse error naming %s, got %v", dailyTemplate, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, dailyTemplate), []byte("{{.Missing}}"), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	r, err := newRenderer(dir, i18n.Default)
+	if err != nil {
+		t.Fatalf("newRenderer() returned error: %v", err)
+	}
+	if _, err := buildDailyBody(r, "HITSZ-OpenAuto", nil, nil, nil, nil, nil, 0); err == nil {
+		t.Errorf("expected execution error for unknown field")
+	}
+}
+
+func TestTemplateData_CourseMeta(t *testing.T) {
+	dir := t.TempDir()
+	weekly := `{{define "weekly-courses"}}{{range .}}{{.Course.Code}} {{.Course.Credits}}` + "\n" +
+		`{{range .Categories}}{{range .Commits}}- {{.Course.Department}}` + "\n" + `{{end}}{{end}}{{end}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, weeklyTemplate), []byte(weekly), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	r, err := newRenderer(dir, i18n.Default)
+	if err != nil {
+		t.Fatalf("newRenderer() returned error: %v", err)
+	}
+	meta := CourseMeta{Name: "电路原理", Code: "EE3001", Credits: 3.5, Department: "电子与信息工程学院"}
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "EE3001", Course: meta},
+	}
+
+	section, err := buildUpdatesSection(r, "course", commits, nil, nil, "HITSZ-OpenAuto")
+	if err != nil {
+		t.Fatalf("buildUpdatesSection() returned error: %v", err)
+	}
+	if !strings.Contains(section, "EE3001 3.5\n- 电子与信息工程学院\n") {
+		t.Errorf("course metadata should reach the templates, got:\n%s", section)
+	}
+	if views := newFirstTimerViews(i18n.Default, []FirstTimer{{First: commits[0], Total: 1}}, nil, "HITSZ-OpenAuto"); views[0].Course.Code != "EE3001" {
+		t.Errorf("first-timer view lost course metadata: %+v", views[0])
+	}
+}
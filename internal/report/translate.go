@@ -0,0 +1,110 @@
+// 英文周报的 AI 翻译：将中文摘要、课程名和院系名译为英文，译文缓存在 cache_dir 中
+package report
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/openai"
+)
+
+const translationsCacheFile = "translations.json"
+
+// textTranslator 将中文文本译为英文，由 openai.Translator 实现。
+type textTranslator interface {
+	Translate(text string) (string, error)
+}
+
+// translateWeekly 读取译文缓存，翻译英文周报所需的文本后写回缓存。
+// 翻译不会中断周报生成：缓存读取失败时只记录日志，英文周报不含摘要、课程名保留中文。
+func translateWeekly(cfg *config.Config, summary string, entries []CommitEntry, repoTitles, departments map[string]string) weeklyText {
+	path := filepath.Join(cfg.CacheDir, translationsCacheFile)
+	cache, err := openai.LoadTranslationCache(path)
+	if err != nil {
+		log.Printf("Failed to load translation cache: %v", err)
+		return weeklyText{RepoTitles: repoTitles, Departments: departments}
+	}
+	text := translateWeeklyText(openai.NewTranslator(cfg.Translation.Glossary, cache), summary, entries, repoTitles, departments)
+	if err := cache.Save(path); err != nil {
+		log.Printf("Failed to save translation cache: %v", err)
+	}
+	return text
+}
+
+// translateWeeklyText 翻译摘要以及 entries 涉及的课程名和院系名。单条翻译失败时记录日志并保留原文，
+// 摘要翻译失败时英文周报不含摘要。
+func translateWeeklyText(tr textTranslator, summary string, entries []CommitEntry, repoTitles, departments map[string]string) weeklyText {
+	translate := func(source string) string {
+		translated, err := tr.Translate(source)
+		if err != nil {
+			log.Printf("Translation failed, keeping original: %v", err)
+			return source
+		}
+		return translated
+	}
+
+	// 只翻译本周出现的课程，避免首次运行时为所有仓库调用 API
+	titles := make(map[string]string, len(repoTitles))
+	for repo, title := range repoTitles {
+		titles[repo] = title
+	}
+	for _, repo := range weeklyRepos(entries) {
+		if title, ok := repoTitles[repo]; ok {
+			titles[repo] = translate(title)
+		}
+	}
+
+	translatedDepartments := make(map[string]string, len(departments))
+	names := make(map[string]string) // 院系名 -> 译名，同一院系只翻译一次
+	for repo, name := range departments {
+		if _, ok := names[name]; !ok {
+			names[name] = translate(name)
+		}
+		translatedDepartments[repo] = names[name]
+	}
+
+	return weeklyText{
+		Summary:     translateSummary(tr, summary),
+		RepoTitles:  titles,
+		Departments: translatedDepartments,
+	}
+}
+
+// translateSummary 翻译摘要正文并换上英文标题，返回清理后的段落，失败时返回空字符串。
+func translateSummary(tr textTranslator, summary string) string {
+	body := strings.TrimSpace(summary)
+	if body == "" {
+		return ""
+	}
+	heading := ""
+	if first, rest, _ := strings.Cut(body, "\n"); strings.TrimSpace(first) == summaryHeading(i18n.Default) {
+		heading = summaryHeading(i18n.EN) + "\n\n"
+		body = strings.TrimSpace(rest)
+	}
+	translated, err := tr.Translate(body)
+	if err != nil {
+		log.Printf("Summary translation failed, omitting it from the English report: %v", err)
+		return ""
+	}
+	return sanitizeSummary(heading + translated)
+}
+
+// weeklyRepos 返回 entries 及其同批次提交涉及的仓库，按仓库名排序。
+func weeklyRepos(entries []CommitEntry) []string {
+	seen := make(map[string]struct{})
+	for _, commit := range entries {
+		for _, repo := range batchRepos(commit, nil) {
+			seen[repo] = struct{}{}
+		}
+	}
+	repos := make([]string, 0, len(seen))
+	for repo := range seen {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
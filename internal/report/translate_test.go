@@ -0,0 +1,76 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+)
+
+// fakeTranslator 按固定的映射翻译，未收录的文本返回错误。
+type fakeTranslator struct {
+	translations map[string]string
+	calls        []string
+}
+
+func (f *fakeTranslator) Translate(text string) (string, error) {
+	f.calls = append(f.calls, text)
+	if translated, ok := f.translations[text]; ok {
+		return translated, nil
+	}
+	return "", errors.New("not translated")
+}
+
+func TestTranslateWeeklyText(t *testing.T) {
+	tr := &fakeTranslator{translations: map[string]string{
+		"电路原理":           "Principles of Electric Circuits",
+		"电气工程":           "Electrical Engineering",
+		"- 电路原理：新增期末试卷。": "- Principles of Electric Circuits: added final exam papers.",
+	}}
+	entries := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "EE3001"},
+		{AuthorName: "李四", Date: time.Date(2026, 2, 10, 11, 0, 0, 0, time.UTC), Message: "整理目录", RepoName: "CS1001"},
+	}
+	titles := map[string]string{"EE3001": "电路原理", "CS1001": "计算机导论", "MATH1001": "高等数学"}
+	departments := map[string]string{"EE3001": "电气工程", "CS1001": "计算机"}
+
+	text := translateWeeklyText(tr, "## 本周更新摘要\n\n- 电路原理：新增期末试卷。\n", entries, titles, departments)
+
+	if want := "## Weekly Summary\n\n- Principles of Electric Circuits: added final exam papers."; text.Summary != want {
+		t.Errorf("Summary = %q, want %q", text.Summary, want)
+	}
+	if text.RepoTitles["EE3001"] != "Principles of Electric Circuits" || text.RepoTitles["CS1001"] != "计算机导论" {
+		t.Errorf("RepoTitles = %v, failed translations should keep the original", text.RepoTitles)
+	}
+	if text.Departments["EE3001"] != "Electrical Engineering" || text.Departments["CS1001"] != "计算机" {
+		t.Errorf("Departments = %v", text.Departments)
+	}
+	for _, call := range tr.calls {
+		if call == "高等数学" {
+			t.Errorf("courses without commits this week should not be translated")
+		}
+	}
+	if titles["EE3001"] != "电路原理" {
+		t.Errorf("input titles should not be modified")
+	}
+
+	r, err := newRenderer("", i18n.EN)
+	if err != nil {
+		t.Fatalf("newRenderer() returned error: %v", err)
+	}
+	page, err := buildWeeklyReport(r, "course", SummaryContext{StartTime: entries[0].Date, NowBJT: entries[1].Date}, WeeklyAggregate{Commits: entries}, entries, text, "HITSZ-OpenAuto")
+	if err != nil {
+		t.Fatalf("buildWeeklyReport() returned error: %v", err)
+	}
+	for _, want := range []string{"title: AUTO Weekly 2026-02-10 - 2026-02-10", "## Weekly Summary", "### [Principles of Electric Circuits](https://github.com/HITSZ-OpenAuto/EE3001) (1 commits)"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("English report missing %q:\n%s", want, page)
+		}
+	}
+
+	if got := translateWeeklyText(tr, "## 本周更新摘要\n\n无法翻译的摘要", nil, titles, nil).Summary; got != "" {
+		t.Errorf("failed summary translation should be omitted, got %q", got)
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
-	"github.com/HITSZ-OpenAuto/hoa-news/internal/github"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/categorize"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/config"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/identity"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/openai"
 	"github.com/HITSZ-OpenAuto/hoa-news/internal/utils"
 	"gopkg.in/yaml.v3"
@@ -23,11 +25,21 @@ var ErrNoWeeklyCommits = errors.New("no commits found in the given period of tim
 
 // CommitEntry 表示一条 commit 记录。
 type CommitEntry struct {
-	AuthorName  string
-	AuthorLogin string
-	Date        time.Time
-	Message     string
-	RepoName    string
+	AuthorName   string              // 经别名归并后的展示名
+	AuthorLogin  string              // 经别名归并后的 GitHub 登录名，可能为空
+	AuthorAvatar string              // 别名文件中指定的头像地址，可能为空
+	CoAuthors    []identity.Identity // Co-authored-by 尾注中的共同作者
+	Date         time.Time
+	Message      string
+	RepoName     string
+	SHA          string              // commit SHA
+	URL          string              // commit 页面地址
+	PRNumber     int                 // 关联的 PR 编号，未关联时为 0
+	PRURL        string              // 关联的 PR 页面地址
+	Files        []string            // 变更的文件列表，仅在开启 categories.fetch_files 时拉取
+	Category     categorize.Category // 收集阶段确定的类别，为空时渲染前按内置规则分类
+	Batch        []CommitEntry       // 去重阶段合并进来的同批次提交，不含自身
+	Course       CourseMeta          // 所在仓库 readme.toml 中的课程元数据，未能获取时为零值
 }
 
 // SummaryContext 保存一次周报生成的运行上下文，
@@ -43,50 +55,98 @@ type SummaryContext struct {
 // WeeklyAggregate 保存数据聚合阶段的结果，
 // 供后续渲染 markdown 和生成摘要使用。
 type WeeklyAggregate struct {
-	Commits  []CommitEntry     // 过滤 bot 后的 commit 列表
-	RepoName map[string]string // repo 名 -> 课程名的映射（无课程名则回退为 repo 名）
+	Commits     []CommitEntry         // 过滤 bot 后的 commit 列表
+	RepoName    map[string]string     // repo 名 -> 课程名的映射（无课程名则回退为 repo 名）
+	Courses     map[string]CourseMeta // repo 名 -> readme.toml 中的课程元数据
+	FirstTimers []FirstTimer          // 本周完成第一次贡献的贡献者
+	Automated   int                   // 被识别为 bot 或自动化操作的提交数，未开启 bots.show_count 时为 0
 }
 
 // Summary 是周报生成的入口函数，编排流程：
 // 构建上下文 → 聚合数据 → 渲染内容 → 写入文件。
-func Weekly(orgName string, publicRepos map[string]struct{}) error {
+// cfg.Languages 中的每种语言各写一份周报和索引，非默认语言的文件名带语言后缀，如 index.en.md。
+func Weekly(orgName string, publicRepos map[string]struct{}, cfg *config.Config) error {
+	renderers, err := newRenderers(cfg) // 先加载模板，模板有误时不必拉取数据
+	if err != nil {
+		return err
+	}
 	ctx := buildSummaryContext(time.Now().UTC())
-	agg := collectWeeklyData(ctx, orgName, publicRepos)
+	opts := newCollectOptions(cfg, true)
+	agg := collectWeeklyData(ctx, orgName, publicRepos, opts)
+	if cfg.Bots.ShowCount {
+		agg.Automated = opts.drops.count(ruleBot, ruleAutomation)
+	}
 
 	if len(agg.Commits) == 0 {
 		return ErrNoWeeklyCommits
 	}
+	agg.FirstTimers = detectFirstTimers(orgName, agg.Commits, ctx.StartTime, cfg.CacheDir)
 
-	frontMatter, err := GenerateWeeklyFrontMatter(ctx.StartTime, ctx.NowBJT)
+	entries := dedupeCommits(agg.Commits, cfg.Dedupe.Window) // 统计仍基于去重前的 commit
+	departments := courseDepartments(entries, agg.Courses, cfg.Departments)
+	summary, err := weeklySummary(cfg, renderers, entries, agg.RepoName, departments, orgName)
 	if err != nil {
-		return fmt.Errorf("failed to generate front matter: %w", err)
-	}
-	markdownReport := BuildMarkdown(agg.Commits, agg.RepoName, orgName)
-
-	summarySection := generateSummarySection(markdownReport)
-
-	var finalReport strings.Builder
-	fmt.Fprintf(&finalReport, "---\n%s---\n\n", frontMatter)
-	if summarySection != "" {
-		finalReport.WriteString(summarySection)
-		finalReport.WriteString("\n\n")
+		return err
 	}
-	finalReport.WriteString(markdownReport)
-
 	if err := os.MkdirAll(ctx.WeeklyDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create weekly directory %q: %w", ctx.WeeklyDir, err)
 	}
-	if err := os.WriteFile(ctx.ReportPath, []byte(finalReport.String()), 0o644); err != nil {
-		return fmt.Errorf("failed to write weekly report %q: %w", ctx.ReportPath, err)
+	for _, r := range renderers {
+		text := weeklyText{RepoTitles: agg.RepoName, Departments: departments}
+		switch {
+		case r.lang == i18n.Default:
+			text.Summary = sanitizeSummary(summary)
+		case r.lang == i18n.EN && cfg.Translation.Enabled:
+			text = translateWeekly(cfg, summary, entries, agg.RepoName, departments)
+		}
+		page, err := buildWeeklyReport(r, cfg.Weekly.Layout, ctx, agg, entries, text, orgName)
+		if err != nil {
+			return err
+		}
+		path := r.lang.Path(ctx.ReportPath)
+		if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+			return fmt.Errorf("failed to write weekly report %q: %w", path, err)
+		}
+		indexPath := r.lang.Path(ctx.WeeklyIndexPath)
+		if err := WriteWeeklyIndex(r.lang, indexPath, ctx.NowBJT); err != nil {
+			return fmt.Errorf("failed to update weekly index %q: %w", indexPath, err)
+		}
 	}
-
-	if err := WriteWeeklyIndex(ctx.WeeklyIndexPath, ctx.NowBJT); err != nil {
-		return fmt.Errorf("failed to update weekly index %q: %w", ctx.WeeklyIndexPath, err)
+	if cfg.Output.JSON {
+		window := ReportWindow{Start: ctx.StartTime.UTC(), End: ctx.NowBJT.UTC().Truncate(time.Second)}
+		doc := newReportDocument(ReportKindWeekly, orgName, window, agg.Commits, agg.Courses, nil, nil, agg.Automated)
+		if err := writeReportJSON(reportJSONPath(ctx.ReportPath), doc); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// weeklyText 是周报中随语言变化的数据，英文周报开启翻译时为 AI 译文。
+type weeklyText struct {
+	Summary     string            // 已清理的摘要段落，含标题，为空时不输出
+	RepoTitles  map[string]string // repo 名 -> 课程名
+	Departments map[string]string // repo 名 -> 院系名
+}
+
+// buildWeeklyReport 使用 r 中的模板按 layout 渲染完整的周报页面，包括 front matter。
+func buildWeeklyReport(r *renderer, layout string, ctx SummaryContext, agg WeeklyAggregate, entries []CommitEntry, text weeklyText, orgName string) (string, error) {
+	frontMatter, err := GenerateWeeklyFrontMatter(r.lang, ctx.StartTime, ctx.NowBJT)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate front matter: %w", err)
+	}
+	data := newWeeklyData(r.lang, layout, entries, text.RepoTitles, text.Departments, orgName)
+	data.Summary = text.Summary
+	data.FirstTimers = newFirstTimerViews(r.lang, agg.FirstTimers, text.RepoTitles, orgName)
+	data.Stats = newStatsView(agg)
+	body, err := r.execute(weeklyTemplate, data)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + frontMatter + "---\n\n" + body, nil
+}
+
 // buildSummaryContext 根据当前 UTC 时间计算时间窗口和输出路径，
 // 返回贯穿整个流程的 SummaryContext。
 func buildSummaryContext(nowUTC time.Time) SummaryContext {
@@ -96,104 +156,60 @@ func buildSummaryContext(nowUTC time.Time) SummaryContext {
 		0, 0, 0, 0, utils.BeijingTimeZone,
 	).AddDate(0, 0, -7)
 
-	weeklyDir := fmt.Sprintf("news/weekly/weekly-%s", start.Format("2006-01-02"))
+	weeklyDir := fmt.Sprintf("%s/weekly-%s", weeklyRoot, start.Format("2006-01-02"))
 
 	return SummaryContext{
 		NowBJT:          nowBJT,
 		StartTime:       start,
 		WeeklyDir:       weeklyDir,
 		ReportPath:      weeklyDir + "/index.md",
-		WeeklyIndexPath: "news/weekly/index.md",
+		WeeklyIndexPath: weeklyRoot + "/index.md",
 	}
 }
 
 // collectWeeklyData 遍历所有公开仓库，拉取时间窗口内的 commit，
-// 过滤 bot 提交，并尝试获取课程名称，返回聚合结果。
-func collectWeeklyData(ctx SummaryContext, orgName string, publicRepos map[string]struct{}) WeeklyAggregate {
-	var (
-		mu sync.Mutex
-		wg sync.WaitGroup
-		// 所有协程可见、共享的信息，需要保护
-		commits   = make([]CommitEntry, 0)  // 过滤 bot 后的 commit 列表，无序
-		repoNames = make(map[string]string) // repo 名 -> 课程名的映射
-		goLimit   = make(chan struct{}, summaryGoroutineLimit)
-	)
-
-	for repo := range publicRepos {
-		wg.Add(1)
-		go func(repo string) {
-			defer wg.Done()
-
-			goLimit <- struct{}{}        // 获取限流令牌
-			defer func() { <-goLimit }() // 释放令牌
-
-			repoCommits, err := github.ListCommitsSince(orgName, repo, ctx.StartTime.Format(time.RFC3339))
-			if err != nil {
-				log.Printf("Failed to fetch commits for %s: %v", repo, err)
-				return
-			}
-
-			localCommits := make([]CommitEntry, 0, len(repoCommits)) // 区分本地和全局，是为了减少锁的粒度，提升性能
-			for _, commit := range repoCommits {
-				authorName := commit.Commit.Author.Name
-				authorLogin := ""
-				if commit.Author != nil {
-					authorLogin = commit.Author.Login
-				}
-				if utils.IsBot(authorName, authorLogin) {
-					continue // 过滤掉 bot 提交，比如 actions 自动生成的就不需要计数
-				}
-				if !utils.IsChineseCommit(commit.Commit.Message) {
-					continue // 只保留中文提交，过滤代码提交等非中文信息
-				}
-
-				date, err := time.Parse(time.RFC3339, commit.Commit.Author.Date)
-				if err != nil {
-					continue
-				}
-				date = date.In(utils.BeijingTimeZone)
-				localCommits = append(localCommits, CommitEntry{
-					AuthorName:  authorName,
-					AuthorLogin: authorLogin,
-					Date:        date,
-					Message:     commit.Commit.Message,
-					RepoName:    repo,
-				})
-			}
-
-			if len(localCommits) == 0 {
-				log.Printf("Finished commits process for %s (no valid commits)", repo)
-				return
-			} // 仅当存在有效提交时，尝试获取课程名称，减少不必要的 API 调用
-
-			var courseName string
-			if name, err := fetchCourseName(orgName, repo); err == nil && name != "" {
-				courseName = name
-			}
-
-			mu.Lock()
-			commits = append(commits, localCommits...)
-			if courseName != "" {
-				repoNames[repo] = courseName
-			}
-			mu.Unlock()
-
-			log.Printf("Finished commits process for %s", repo)
-		}(repo)
-	}
-
-	wg.Wait()
-
-	log.Printf("Commit collection complete, %d total valid commits", len(commits))
+// 过滤 bot 提交、归并作者身份、分类，并尝试获取课程元数据，返回聚合结果。
+func collectWeeklyData(ctx SummaryContext, orgName string, publicRepos map[string]struct{}, opts collectOptions) WeeklyAggregate {
+	commits, courses := collectCommits(orgName, publicRepos, ctx.StartTime, summaryGoroutineLimit, opts)
 	return WeeklyAggregate{
 		Commits:  commits,
-		RepoName: repoNames,
+		RepoName: courseTitles(courses),
+		Courses:  courses,
 	}
 }
 
-// generateSummarySection 调用 OpenAI 生成周报摘要段。
+// weeklySummary 以默认语言按 summary_layout 渲染的「更新内容」为输入，生成中文 AI 摘要的原文。
+// 摘要写入中文周报，开启翻译时译文写入英文周报；两者都不输出时不调用 API。
+func weeklySummary(cfg *config.Config, renderers []*renderer, entries []CommitEntry, repoTitles, departments map[string]string, orgName string) (string, error) {
+	var r *renderer
+	needed := false
+	for _, candidate := range renderers {
+		switch {
+		case candidate.lang == i18n.Default:
+			r, needed = candidate, true
+		case candidate.lang == i18n.EN && cfg.Translation.Enabled:
+			needed = true
+		}
+	}
+	if !needed {
+		return "", nil
+	}
+	if r == nil {
+		var err error
+		if r, err = newRenderer(cfg.TemplatesDir, i18n.Default); err != nil {
+			return "", err
+		}
+	}
+	summaryInput, err := buildUpdatesSection(r, cfg.Weekly.SummaryLayout, entries, repoTitles, departments, orgName)
+	if err != nil {
+		return "", err
+	}
+	return generateSummary(summaryInput), nil
+}
+
+// generateSummary 调用 OpenAI 生成周报摘要段的原文。
 // 如果调用失败或返回 __NO_SUMMARY__，则返回空字符串（不插入摘要）。
-func generateSummarySection(markdownReport string) string {
+func generateSummary(markdownReport string) string {
 	summaryText, err := openai.GenerateWeeklySummary(markdownReport)
 	if err != nil {
 		log.Printf("AI summary generation failed: %v, using original report instead.", err)
@@ -203,8 +219,14 @@ func generateSummarySection(markdownReport string) string {
 	if summaryText == "__NO_SUMMARY__" {
 		return ""
 	}
+	return summaryText
+}
 
-	// 对 AI 生成的摘要进行清理，移除控制字符和多余空白，确保在 Markdown 中安全显示。
+// sanitizeSummary 对 AI 生成的摘要进行清理，移除控制字符和多余空白，确保在 Markdown 中安全显示。
+func sanitizeSummary(summaryText string) string {
+	if summaryText == "" {
+		return ""
+	}
 	lines := strings.Split(summaryText, "\n")
 	for i, line := range lines {
 		lines[i] = utils.SanitizeLinkLabel(line)
@@ -213,16 +235,16 @@ func generateSummarySection(markdownReport string) string {
 	return strings.Join(lines, "\n")
 }
 
-// WriteWeeklyIndex 更新周报索引文件的标题、日期、描述。
-func WriteWeeklyIndex(path string, now time.Time) error {
+// WriteWeeklyIndex 用 lang 语言更新周报索引文件的标题、日期、描述。
+func WriteWeeklyIndex(lang i18n.Lang, path string, now time.Time) error {
 	fm := struct {
 		Title       string `yaml:"title"`
 		Date        string `yaml:"date"`
 		Description string `yaml:"description"`
 	}{
-		Title:       "AUTO 周报",
+		Title:       lang.T("weekly.index.title"),
 		Date:        now.Format("2006-01-02"),
-		Description: fmt.Sprintf("AUTO 周报是由 ChatGPT 每周五发布的一份简报，最近更新于 %s。", now.Format("2006-01-02")),
+		Description: lang.T("weekly.index.description", now.Format("2006-01-02")),
 	}
 	out, err := yaml.Marshal(&fm)
 	if err != nil {
@@ -232,66 +254,144 @@ func WriteWeeklyIndex(path string, now time.Time) error {
 	return os.WriteFile(path, []byte(content), 0o644)
 }
 
-// BuildMarkdown 将 commit 列表按日期降序渲染为 markdown 格式的「更新内容」段落。
+// BuildMarkdown 使用内置模板将 commit 列表按日期降序渲染为 markdown 格式的「更新内容」段落，
+// 每天内部按类别分组，日常维护类提交折叠为一行摘要，批量提交合并为一条并列出涉及的课程。
 func BuildMarkdown(commits []CommitEntry, repoTitles map[string]string, orgName string) string {
-	if len(commits) == 0 {
-		return ""
+	return buildDefaultUpdates(config.LayoutDay, commits, repoTitles, nil, orgName)
+}
+
+// BuildCourseMarkdown 使用内置模板将 commit 列表按课程渲染为「更新内容」段落。
+// 课程按提交数降序排列，每门课程内部按类别分组、按日期降序列出，日常维护类提交折叠为一行。
+// 涉及多门课程的批量提交单独列在「跨课程批量操作」下。
+func BuildCourseMarkdown(commits []CommitEntry, repoTitles map[string]string, orgName string) string {
+	return buildDefaultUpdates(config.LayoutCourse, commits, repoTitles, nil, orgName)
+}
+
+// buildDefaultUpdates 使用内置模板渲染「更新内容」段落。
+func buildDefaultUpdates(layout string, commits []CommitEntry, repoTitles, departments map[string]string, orgName string) string {
+	return defaultRenderer.mustExecute(weeklyUpdatesTemplate, newWeeklyData(defaultRenderer.lang, layout, commits, repoTitles, departments, orgName))
+}
+
+// buildUpdatesSection 使用 r 中的模板按 layout 渲染「更新内容」段落，未知或为空的 layout 按天分组。
+// departments 为 repo 名 -> 院系名的映射，仅 department 布局使用。没有提交时返回空字符串。
+func buildUpdatesSection(r *renderer, layout string, commits []CommitEntry, repoTitles, departments map[string]string, orgName string) (string, error) {
+	return r.execute(weeklyUpdatesTemplate, newWeeklyData(r.lang, layout, commits, repoTitles, departments, orgName))
+}
+
+// newWeeklyData 按 layout 分组并构建 lang 语言的周报模板数据。
+func newWeeklyData(lang i18n.Lang, layout string, commits []CommitEntry, repoTitles, departments map[string]string, orgName string) WeeklyData {
+	data := WeeklyData{Org: orgName, Empty: len(commits) == 0, Layout: layout}
+	switch layout {
+	case config.LayoutCourse:
+		single, batches := splitBatches(commits, repoTitles)
+		data.Courses = newCourseViews(lang, "###", single, repoTitles, orgName)
+		data.Batches = newBatchViews(lang, batches, repoTitles, orgName)
+	case config.LayoutDepartment:
+		groups, batches := groupByDepartment(commits, departments, repoTitles)
+		for _, group := range groups {
+			data.Departments = append(data.Departments, DepartmentView{
+				Title:   departmentTitle(group.Department),
+				Count:   group.Count,
+				Courses: newCourseViews(lang, "####", group.Commits, repoTitles, orgName),
+			})
+		}
+		data.Batches = newBatchViews(lang, batches, repoTitles, orgName)
+	default:
+		data.Layout = config.LayoutDay
+		data.Days = newDayViews(lang, commits, repoTitles, orgName)
 	}
+	return data
+}
+
+// newDayViews 将 commit 按日期降序排列并按天分组，每天内部再按类别分组。
+func newDayViews(lang i18n.Lang, commits []CommitEntry, repoTitles map[string]string, orgName string) []DayView {
 	// 按日期降序排序，最新的 commit 在前面
 	sort.Slice(commits, func(i, j int) bool {
 		return commits[i].Date.After(commits[j].Date)
 	})
 
-	var b strings.Builder
-	b.WriteString("## 更新内容\n\n")
+	var days []DayView
+	for start := 0; start < len(commits); {
+		day := commits[start].Date
+		end := start
+		for end < len(commits) && commits[end].Date.Format("2006-01-02") == day.Format("2006-01-02") {
+			end++
+		}
+		days = append(days, DayView{
+			Weekday:    lang.Weekday(day),
+			Date:       lang.ShortDate(day),
+			Categories: newCategoryViews(lang, "####", commits[start:end], repoTitles, orgName),
+		})
+		start = end
+	}
+	return days
+}
 
-	var prevDate string // 用于检测日期变化，按天分组显示
+// splitBatches 将涉及多门课程的批量提交与单课程提交分开。
+func splitBatches(commits []CommitEntry, repoTitles map[string]string) (single, batches []CommitEntry) {
+	single = make([]CommitEntry, 0, len(commits))
 	for _, commit := range commits {
-		dateStr := commit.Date.Format("2006-01-02")
-		if dateStr != prevDate { // 说明是新的一天了，插入新日期标题
-			fmt.Fprintf(&b, "### %s (%d.%d)\n\n", utils.ChineseWeekday(commit.Date), commit.Date.Month(), commit.Date.Day())
-			prevDate = dateStr
+		if len(batchRepos(commit, repoTitles)) > 1 {
+			batches = append(batches, commit)
+			continue
 		}
-		title := repoTitles[commit.RepoName]
-		if title == "" {
-			title = commit.RepoName
+		single = append(single, commit)
+	}
+	return single, batches
+}
+
+// groupByRepo 按仓库将 commit 分组，同时统计每个仓库的提交数（含去重合并的提交）。
+func groupByRepo(commits []CommitEntry) (map[string][]CommitEntry, map[string]int) {
+	byRepo := make(map[string][]CommitEntry)
+	counts := make(map[string]int)
+	for _, commit := range commits {
+		byRepo[commit.RepoName] = append(byRepo[commit.RepoName], commit)
+		counts[commit.RepoName] += batchSize(commit)
+	}
+	return byRepo, counts
+}
+
+// sortedRepos 返回按提交数降序、课程名升序排列的仓库列表。
+func sortedRepos(counts map[string]int, repoTitles map[string]string) []string {
+	repos := make([]string, 0, len(counts))
+	for repo := range counts {
+		repos = append(repos, repo)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		if counts[repos[i]] != counts[repos[j]] {
+			return counts[repos[i]] > counts[repos[j]]
 		}
-		title = utils.SanitizeInlineText(title)
-		author := utils.SanitizeInlineText(commit.AuthorName)
-		message := utils.SanitizeInlineText(strings.Split(commit.Message, "\n")[0]) // commit message 可能有多行补充信息，只取第一行作为摘要
-		fmt.Fprintf(&b, "- %s 在 [%s](https://github.com/%s/%s) 中提交了信息：%s\n\n", author, title, orgName, commit.RepoName, message)
+		return courseTitle(repos[i], repoTitles) < courseTitle(repos[j], repoTitles)
+	})
+	return repos
+}
+
+// newStatsView 统计提交数、贡献者数、涉及课程数、新贡献者数和自动化操作数。
+func newStatsView(agg WeeklyAggregate) StatsView {
+	authors := make(map[string]struct{})
+	repos := make(map[string]struct{})
+	for _, commit := range agg.Commits {
+		authors[contributorKey(commit)] = struct{}{}
+		repos[commit.RepoName] = struct{}{}
+	}
+	return StatsView{
+		Commits:      len(agg.Commits),
+		Contributors: len(authors),
+		Courses:      len(repos),
+		FirstTimers:  len(agg.FirstTimers),
+		Automated:    agg.Automated,
 	}
-	return b.String()
 }
 
-// GenerateWeeklyFrontMatter 生成周报的 YAML front matter。
-func GenerateWeeklyFrontMatter(startDate time.Time, now time.Time) (string, error) {
+// GenerateWeeklyFrontMatter 生成 lang 语言周报的 YAML front matter，各语言的日期和作者相同。
+func GenerateWeeklyFrontMatter(lang i18n.Lang, startDate time.Time, now time.Time) (string, error) {
 	return utils.GenerateFrontMatter(
-		fmt.Sprintf("AUTO 周报 %s - %s", startDate.Format("2006-01-02"), now.Format("2006-01-02")),
+		lang.T("weekly.title", startDate.Format("2006-01-02"), now.Format("2006-01-02")),
 		now.Format("2006-01-02"),
-		fmt.Sprintf("涵盖 %s 至 %s 的更新", startDate.Format("2006-01-02"), now.Format("2006-01-02")),
+		lang.T("weekly.description", startDate.Format("2006-01-02"), now.Format("2006-01-02")),
 		[]utils.Author{{
 			Name:  "ChatGPT",
 			Link:  "https://github.com/openai",
 			Image: "https://github.com/openai.png",
 		}})
 }
-
-// fetchCourseName 从仓库的 readme.toml 文件中提取课程名称。
-func fetchCourseName(orgName, repoName string) (string, error) {
-	text, err := github.GetRawReadmeToml(orgName, repoName)
-	if err != nil {
-		return "", err
-	}
-	for _, line := range strings.Split(text, "\n") {
-		key, val, found := strings.Cut(line, "=")
-		if !found || strings.TrimSpace(key) != "course_name" {
-			continue
-		}
-		val = strings.TrimSpace(val)
-		if len(val) >= 2 && val[0] == '"' && val[len(val)-1] == '"' {
-			return val[1 : len(val)-1], nil
-		}
-	}
-	return "", fmt.Errorf("course_name not found in readme.toml")
-}
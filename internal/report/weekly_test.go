@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/HITSZ-OpenAuto/hoa-news/internal/i18n"
 )
 
 func TestBuildMarkdown(t *testing.T) {
@@ -139,7 +141,7 @@ func TestGenerateWeeklyFrontMatter(t *testing.T) {
 	startDate := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)
 
-	result, err := GenerateWeeklyFrontMatter(startDate, endDate)
+	result, err := GenerateWeeklyFrontMatter(i18n.Default, startDate, endDate)
 	if err != nil {
 		t.Fatalf("GenerateWeeklyFrontMatter() returned error: %v", err)
 	}
@@ -168,7 +170,7 @@ func TestWriteWeeklyIndex(t *testing.T) {
 	tmpFile := t.TempDir() + "/_index.zh-cn.md"
 	now := time.Date(2026, 2, 13, 12, 0, 0, 0, time.UTC)
 
-	err := WriteWeeklyIndex(tmpFile, now)
+	err := WriteWeeklyIndex(i18n.Default, tmpFile, now)
 	if err != nil {
 		t.Fatalf("WriteWeeklyIndex() returned error: %v", err)
 	}
@@ -259,7 +261,103 @@ func TestBuildMarkdown_EscapeMDXPayload(t *testing.T) {
 	if !strings.Contains(result, "&#123;alert(1)&#125;") {
 		t.Fatalf("escaped message not found, got:\n%s", result)
 	}
-	if !strings.Contains(result, "&lt;/b&gt;&lt;script&gt;alert(1)&lt;/script&gt;") {
+	if !strings.Contains(result, `&lt;/b&gt;&lt;script&gt;alert\(1\)&lt;/script&gt;`) {
 		t.Fatalf("escaped repo title not found, got:\n%s", result)
 	}
 }
+
+func TestBuildCourseMarkdown(t *testing.T) {
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "CS1001"},
+		{AuthorName: "李四", Date: time.Date(2026, 2, 12, 10, 0, 0, 0, time.UTC), Message: "新增实验指导书", RepoName: "CS1001"},
+		{AuthorName: "王五", Date: time.Date(2026, 2, 11, 10, 0, 0, 0, time.UTC), Message: "修正答案错误", RepoName: "MATH1002"},
+		{AuthorName: "赵六", Date: time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC), Message: "格式调整", RepoName: "CS1001"},
+	}
+	repoTitles := map[string]string{"CS1001": "程序设计基础", "MATH1002": "高等数学A（下）"}
+
+	result := BuildCourseMarkdown(commits, repoTitles, "HITSZ-OpenAuto")
+
+	for _, want := range []string{
+		"### [程序设计基础](https://github.com/HITSZ-OpenAuto/CS1001)（3 条提交）",
+		"### [高等数学A（下）](https://github.com/HITSZ-OpenAuto/MATH1002)（1 条提交）",
+		"- 周四 (2.12) 李四：新增实验指导书",
+		"- 共 1 条日常维护提交",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("BuildCourseMarkdown() missing %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Index(result, "程序设计基础") > strings.Index(result, "高等数学A（下）") {
+		t.Errorf("courses with more commits should come first, got:\n%s", result)
+	}
+	if strings.Index(result, "新增实验指导书") > strings.Index(result, "上传期末试卷") {
+		t.Errorf("commits within a course should be sorted newest first, got:\n%s", result)
+	}
+}
+
+func TestBuildUpdatesSection_Layout(t *testing.T) {
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC), Message: "上传期末试卷", RepoName: "CS1001"},
+	}
+	if got, _ := buildUpdatesSection(defaultRenderer, "course", commits, nil, nil, "HITSZ-OpenAuto"); !strings.Contains(got, "### [CS1001]") {
+		t.Errorf("course layout should use course headings, got:\n%s", got)
+	}
+	if got, _ := buildUpdatesSection(defaultRenderer, "department", commits, nil, map[string]string{"CS1001": "计算机"}, "HITSZ-OpenAuto"); !strings.Contains(got, "### 计算机（1 条提交）") {
+		t.Errorf("department layout should use department headings, got:\n%s", got)
+	}
+	if got, _ := buildUpdatesSection(defaultRenderer, "", commits, nil, nil, "HITSZ-OpenAuto"); !strings.Contains(got, "### 周二 (2.10)") {
+		t.Errorf("empty layout should fall back to day headings, got:\n%s", got)
+	}
+}
+
+func TestBuildUpdatesSection_English(t *testing.T) {
+	r, err := newRenderer("", i18n.EN)
+	if err != nil {
+		t.Fatalf("newRenderer() returned error: %v", err)
+	}
+	date := time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC)
+	commits := []CommitEntry{
+		{AuthorName: "张三", Date: date, Message: "上传期末试卷", RepoName: "CS1001"},
+		{AuthorName: "李四", AuthorLogin: "lisi", Date: date, Message: "更新 readme.toml", RepoName: "A",
+			Batch: []CommitEntry{{RepoName: "B"}, {RepoName: "C"}}},
+	}
+	titles := map[string]string{"CS1001": "计算机导论"}
+
+	day, err := buildUpdatesSection(r, "day", cloneCommits(commits), titles, nil, "HITSZ-OpenAuto")
+	if err != nil {
+		t.Fatalf("buildUpdatesSection() returned error: %v", err)
+	}
+	for _, want := range []string{
+		"## Updates",
+		"### Tue (Feb 10)",
+		"#### New Materials",
+		"- 张三 committed to [计算机导论](https://github.com/HITSZ-OpenAuto/CS1001): 上传期末试卷",
+		"[A](https://github.com/HITSZ-OpenAuto/A), [B](https://github.com/HITSZ-OpenAuto/B), [C](https://github.com/HITSZ-OpenAuto/C) (3 courses): 更新 readme.toml (3 commits)",
+	} {
+		if !strings.Contains(day, want) {
+			t.Errorf("day layout missing %q:\n%s", want, day)
+		}
+	}
+
+	course, err := buildUpdatesSection(r, "course", cloneCommits(commits), titles, nil, "HITSZ-OpenAuto")
+	if err != nil {
+		t.Fatalf("buildUpdatesSection() returned error: %v", err)
+	}
+	for _, want := range []string{
+		"### [计算机导论](https://github.com/HITSZ-OpenAuto/CS1001) (1 commits)",
+		"- Tue (Feb 10) 张三: 上传期末试卷",
+		"### Cross-course Batch Operations (1)",
+	} {
+		if !strings.Contains(course, want) {
+			t.Errorf("course layout missing %q:\n%s", want, course)
+		}
+	}
+	if strings.ContainsAny(day+course, "（）、：") {
+		t.Errorf("English output should not contain Chinese punctuation:\n%s\n%s", day, course)
+	}
+
+	stats := r.mustExecute("weekly-stats", newStatsView(WeeklyAggregate{Commits: commits}))
+	if !strings.HasPrefix(stats, "## This Week in Numbers\n\n- **Commits**: 2\n") {
+		t.Errorf("unexpected stats section:\n%s", stats)
+	}
+}
@@ -0,0 +1,401 @@
+package utils
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MarkdownToHTML 将本项目生成的 Markdown 转换为 HTML，供订阅源等需要 HTML 的输出使用。
+// 只支持报告中用到的语法：ATX 标题、分隔线、（嵌套）列表、引用、围栏代码块、表格、段落，
+// 以及行内的链接、图片、代码、粗体和斜体。不带属性的 details、summary 等少数 HTML 标签原样输出，
+// 文本中已有的 HTML 实体保持不变；提交信息等外部文本应先经过 SanitizeInlineText 等函数转义，
+// 链接地址只保留安全的协议。
+func MarkdownToHTML(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+		case strings.HasPrefix(trimmed, "```"):
+			i = writeCodeBlock(&b, lines, i)
+		case mdHeadingRe.MatchString(trimmed):
+			m := mdHeadingRe.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+		case mdRuleRe.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+		case mdHTMLRe.MatchString(trimmed):
+			// 只原样输出行首的标签，其余内容按行内语法转换
+			tag := mdHTMLRe.FindString(trimmed)
+			b.WriteString(tag + renderInline(trimmed[len(tag):]) + "\n")
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			i = writeBlockquote(&b, lines, i)
+		case isTableStart(lines, i):
+			i = writeTable(&b, lines, i)
+		case mdListItemRe.MatchString(line):
+			i = writeList(&b, lines, i)
+		default:
+			i = writeParagraph(&b, lines, i)
+		}
+	}
+	return b.String()
+}
+
+// mdAllowedTags 是允许原样输出的 HTML 标签，且不能带属性，其余标签一律转义。
+const mdAllowedTags = "details|summary|br|sub|sup|kbd"
+
+var (
+	mdHeadingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	mdRuleRe     = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+	mdHTMLRe     = regexp.MustCompile(`^</?(?:` + mdAllowedTags + `)\s*/?>`)
+	mdListItemRe = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdTableSepRe = regexp.MustCompile(`^\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?$`)
+	mdEntityRe   = regexp.MustCompile(`^&(?:[a-zA-Z][a-zA-Z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`)
+	mdAutolinkRe = regexp.MustCompile(`^<(https?://[^\s<>]+)>`)
+)
+
+// writeCodeBlock 输出从 lines[start] 开始的围栏代码块，返回代码块之后的行号。
+func writeCodeBlock(b *strings.Builder, lines []string, start int) int {
+	lang := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[start]), "```"))
+	if lang != "" {
+		b.WriteString(`<pre><code class="language-` + html.EscapeString(lang) + `">`)
+	} else {
+		b.WriteString("<pre><code>")
+	}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+			i++
+			break
+		}
+		b.WriteString(html.EscapeString(lines[i]) + "\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// writeBlockquote 输出连续的引用行，引用内容按 Markdown 递归转换。
+func writeBlockquote(b *strings.Builder, lines []string, start int) int {
+	var inner []string
+	i := start
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		trimmed = strings.TrimPrefix(trimmed, ">")
+		inner = append(inner, strings.TrimPrefix(trimmed, " "))
+	}
+	b.WriteString("<blockquote>\n" + MarkdownToHTML(strings.Join(inner, "\n")) + "</blockquote>\n")
+	return i
+}
+
+// isTableStart 判断 lines[i] 是否为表头：以竖线开头且下一行是分隔行。
+func isTableStart(lines []string, i int) bool {
+	return strings.HasPrefix(strings.TrimSpace(lines[i]), "|") &&
+		i+1 < len(lines) && mdTableSepRe.MatchString(strings.TrimSpace(lines[i+1]))
+}
+
+// writeTable 输出表格，返回表格之后的行号。
+func writeTable(b *strings.Builder, lines []string, start int) int {
+	b.WriteString("<table>\n<thead>\n")
+	writeTableRow(b, lines[start], "th")
+	b.WriteString("</thead>\n<tbody>\n")
+	i := start + 2
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, "|") {
+			break
+		}
+		writeTableRow(b, trimmed, "td")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// writeTableRow 按未转义的竖线拆分单元格并输出一行。
+func writeTableRow(b *strings.Builder, line, cell string) {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var current strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			current.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(line[i])
+		}
+	}
+	cells = append(cells, current.String())
+
+	b.WriteString("<tr>")
+	for _, c := range cells {
+		b.WriteString("<" + cell + ">" + renderInline(strings.TrimSpace(c)) + "</" + cell + ">")
+	}
+	b.WriteString("</tr>\n")
+}
+
+// writeList 输出从 lines[start] 开始的列表，按缩进嵌套。列表项之间允许空行，
+// 缩进的非列表行视为上一项的续行。返回列表之后的行号。
+func writeList(b *strings.Builder, lines []string, start int) int {
+	type level struct {
+		indent int
+		tag    string
+	}
+	var stack []level
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			// 空行之后仍是列表项或缩进的续行时，列表继续
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next < len(lines) && (mdListItemRe.MatchString(lines[next]) || indentOf(lines[next]) > 0) {
+				continue
+			}
+			break
+		}
+		m := mdListItemRe.FindStringSubmatch(line)
+		if m == nil {
+			if indentOf(line) == 0 && i > start && strings.TrimSpace(lines[i-1]) == "" {
+				break
+			}
+			b.WriteString("\n" + renderInline(strings.TrimSpace(line)))
+			continue
+		}
+		indent := len(m[1])
+		tag := "ul"
+		if m[2][0] >= '0' && m[2][0] <= '9' {
+			tag = "ol"
+		}
+		for len(stack) > 0 && indent < stack[len(stack)-1].indent {
+			b.WriteString("</li>\n</" + stack[len(stack)-1].tag + ">\n")
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) > 0 && indent == stack[len(stack)-1].indent {
+			b.WriteString("</li>\n")
+		} else {
+			if len(stack) > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("<" + tag + ">\n")
+			stack = append(stack, level{indent: indent, tag: tag})
+		}
+		b.WriteString("<li>" + renderInline(m[3]))
+	}
+	for len(stack) > 0 {
+		b.WriteString("</li>\n</" + stack[len(stack)-1].tag + ">\n")
+		stack = stack[:len(stack)-1]
+	}
+	return i
+}
+
+// indentOf 返回行首空白的宽度，制表符按 4 个空格计算。
+func indentOf(line string) int {
+	n := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// writeParagraph 将连续的普通文本行合并为一个段落。
+func writeParagraph(b *strings.Builder, lines []string, start int) int {
+	var parts []string
+	i := start
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || (i > start && startsBlock(lines, i)) {
+			break
+		}
+		parts = append(parts, renderInline(trimmed))
+	}
+	b.WriteString("<p>" + strings.Join(parts, "\n") + "</p>\n")
+	return i
+}
+
+// startsBlock 判断 lines[i] 是否会开启一个新的块，用于结束段落。
+func startsBlock(lines []string, i int) bool {
+	trimmed := strings.TrimSpace(lines[i])
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, ">") ||
+		mdHeadingRe.MatchString(trimmed) || mdHTMLRe.MatchString(trimmed) ||
+		mdListItemRe.MatchString(lines[i]) || isTableStart(lines, i)
+}
+
+// renderInline 转换行内语法，其余文本按 HTML 转义。
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!|<>&", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+		case c == '`':
+			end := strings.IndexByte(s[i+1:], '`')
+			if end < 0 {
+				b.WriteString("`")
+				i++
+				continue
+			}
+			b.WriteString("<code>" + html.EscapeString(s[i+1:i+1+end]) + "</code>")
+			i += end + 2
+		case c == '!' && strings.HasPrefix(s[i+1:], "["):
+			label, href, n, ok := parseLink(s[i+1:])
+			if !ok {
+				b.WriteString("!")
+				i++
+				continue
+			}
+			if safe, ok := safeLinkURL(href); ok {
+				b.WriteString(`<img src="` + html.EscapeString(safe) + `" alt="` + html.EscapeString(label) + `">`)
+			} else {
+				b.WriteString(html.EscapeString(label))
+			}
+			i += n + 1
+		case c == '[':
+			label, href, n, ok := parseLink(s[i:])
+			if !ok {
+				b.WriteString("[")
+				i++
+				continue
+			}
+			if safe, ok := safeLinkURL(href); ok {
+				b.WriteString(`<a href="` + html.EscapeString(safe) + `">` + renderInline(label) + "</a>")
+			} else {
+				b.WriteString(renderInline(label)) // 不安全的链接只保留文本
+			}
+			i += n
+		case c == '*' && strings.HasPrefix(s[i:], "**"):
+			end := strings.Index(s[i+2:], "**")
+			if end <= 0 {
+				b.WriteString("**")
+				i += 2
+				continue
+			}
+			b.WriteString("<strong>" + renderInline(s[i+2:i+2+end]) + "</strong>")
+			i += end + 4
+		case c == '*':
+			end := strings.IndexByte(s[i+1:], '*')
+			if end <= 0 || s[i+1] == ' ' {
+				b.WriteString("*")
+				i++
+				continue
+			}
+			b.WriteString("<em>" + renderInline(s[i+1:i+1+end]) + "</em>")
+			i += end + 2
+		case c == '&':
+			if m := mdEntityRe.FindString(s[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+			b.WriteString("&amp;")
+			i++
+		case c == '<':
+			if m := mdAutolinkRe.FindStringSubmatch(s[i:]); m != nil {
+				href := html.EscapeString(m[1])
+				b.WriteString(`<a href="` + href + `">` + href + "</a>")
+				i += len(m[0])
+				continue
+			}
+			if m := mdHTMLRe.FindString(s[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+			b.WriteString("&lt;")
+			i++
+		case c == '>':
+			b.WriteString("&gt;")
+			i++
+		case c == '"':
+			b.WriteString("&#34;")
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// safeLinkURL 校验链接地址，只允许 http、https、mailto 和相对地址，
+// 拒绝 javascript: 等可执行的协议。
+func safeLinkURL(raw string) (string, bool) {
+	safe, ok := SanitizeURL(raw)
+	if !ok {
+		return "", false
+	}
+	parsed, err := url.Parse(safe)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return safe, true
+	}
+	return "", false
+}
+
+// parseLink 解析 s 开头的 [label](url)，label 中允许转义字符和嵌套的方括号，
+// url 中允许成对的圆括号。返回消耗的字节数。
+func parseLink(s string) (label, href string, n int, ok bool) {
+	depth := 0
+	end := -1
+	for i := 0; i < len(s) && end < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 || end+1 >= len(s) || s[end+1] != '(' {
+		return "", "", 0, false
+	}
+	depth = 0
+	for i := end + 1; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[1:end], strings.TrimSpace(s[end+2 : i]), i + 1, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
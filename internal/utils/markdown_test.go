@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML_Blocks(t *testing.T) {
+	src := strings.Join([]string{
+		"## 更新内容",
+		"",
+		"### 新增资料",
+		"",
+		"- [张三](https://github.com/zhangsan) 在 [电路原理](https://github.com/HITSZ-OpenAuto/EE3001) 中提交了信息：上传 **期末** 试卷",
+		"",
+		"- 共 3 条日常维护提交，涉及 A &amp; B",
+		"  - 嵌套项",
+		"",
+		"<details>",
+		"<summary>查看全部 5 门课程</summary>",
+		"",
+		"- [课程A](https://github.com/HITSZ-OpenAuto/A)",
+		"",
+		"</details>",
+		"",
+		"| 课程 | 提交数 |",
+		"| --- | ---: |",
+		`| a\|b | 3 |`,
+		"",
+		"第一行",
+		"第二行 `x < y`",
+	}, "\n")
+	want := strings.Join([]string{
+		"<h2>更新内容</h2>",
+		"<h3>新增资料</h3>",
+		"<ul>",
+		`<li><a href="https://github.com/zhangsan">张三</a> 在 <a href="https://github.com/HITSZ-OpenAuto/EE3001">电路原理</a> 中提交了信息：上传 <strong>期末</strong> 试卷</li>`,
+		"<li>共 3 条日常维护提交，涉及 A &amp; B",
+		"<ul>",
+		"<li>嵌套项</li>",
+		"</ul>",
+		"</li>",
+		"</ul>",
+		"<details>",
+		"<summary>查看全部 5 门课程</summary>",
+		"<ul>",
+		`<li><a href="https://github.com/HITSZ-OpenAuto/A">课程A</a></li>`,
+		"</ul>",
+		"</details>",
+		"<table>",
+		"<thead>",
+		"<tr><th>课程</th><th>提交数</th></tr>",
+		"</thead>",
+		"<tbody>",
+		"<tr><td>a|b</td><td>3</td></tr>",
+		"</tbody>",
+		"</table>",
+		"<p>第一行",
+		"第二行 <code>x &lt; y</code></p>",
+		"",
+	}, "\n")
+	if got := MarkdownToHTML(src); got != want {
+		t.Errorf("MarkdownToHTML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdownToHTML_Inline(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"escaped label", `[课程 \[A\]](https://example.com/a_(b))`, `<a href="https://example.com/a_(b)">课程 [A]</a>`},
+		{"entities kept", "A &lt;b&gt; &amp; c & d", "A &lt;b&gt; &amp; c &amp; d"},
+		{"raw tag", "a <br> b < c", "a <br> b &lt; c"},
+		{"emphasis", "*斜体* 与 **粗体**", "<em>斜体</em> 与 <strong>粗体</strong>"},
+		{"image", `![头像](https://github.com/a.png)`, `<img src="https://github.com/a.png" alt="头像">`},
+		{"autolink", "<https://hoa.moe>", `<a href="https://hoa.moe">https://hoa.moe</a>`},
+		{"unmatched", "[未闭合 * 号", "[未闭合 * 号"},
+		{"unsafe link", "[点我](javascript:alert(1))", "点我"},
+		{"unsafe image", `![x](data:text/html,<b>)`, "x"},
+		{"tag with attributes", `<img src=x onerror="alert(1)">`, "&lt;img src=x onerror=&#34;alert(1)&#34;&gt;"},
+		{"mailto", "[邮件](mailto:a@b.c)", `<a href="mailto:a@b.c">邮件</a>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderInline(tt.src); got != tt.want {
+				t.Errorf("renderInline(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToHTML_TagLineWithTrailingMarkup(t *testing.T) {
+	src := "<details><img src=x onerror=alert(1)>\n<summary>**全部**</summary>"
+	want := "<details>&lt;img src=x onerror=alert(1)&gt;\n<summary><strong>全部</strong></summary>\n"
+	if got := MarkdownToHTML(src); got != want {
+		t.Errorf("MarkdownToHTML(%q) = %q, want %q", src, got, want)
+	}
+}
@@ -64,6 +64,11 @@ func SanitizeLinkLabel(s string) string {
 	return markdownLabelReplacer.Replace(safe)
 }
 
+// SanitizeTableLabel 对 Markdown 表格单元格中的链接标签进行清理，在 SanitizeLinkLabel 的基础上转义竖线。
+func SanitizeTableLabel(s string) string {
+	return strings.ReplaceAll(SanitizeLinkLabel(s), "|", `\|`)
+}
+
 // SanitizeURL 清理 URL 字符串，去除首尾空白并验证其格式是否正确。返回清理后的 URL 和一个布尔值表示是否有效。
 func SanitizeURL(raw string) (string, bool) {
 	trimmed := strings.TrimSpace(raw)
@@ -1,6 +1,10 @@
 package utils
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
 
 func TestSanitizeInlineText(t *testing.T) {
 	tests := []struct {
@@ -59,6 +63,14 @@ func TestSanitizeLinkLabel(t *testing.T) {
 	}
 }
 
+func TestSanitizeTableLabel(t *testing.T) {
+	got := SanitizeTableLabel("a | [b]\n<c>")
+	want := "a \\| \\[b\\] &lt;c&gt;"
+	if got != want {
+		t.Fatalf("SanitizeTableLabel() = %q, want %q", got, want)
+	}
+}
+
 func TestSanitizeURL(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -123,3 +135,22 @@ func TestRenderSafeMarkdownLink(t *testing.T) {
 		t.Fatalf("RenderSafeMarkdownLink() invalid = %q, want %q", gotInvalid, wantInvalid)
 	}
 }
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(TemplateFuncs()).Parse(
+		`{{inline .Text}}|{{link .Text .URL}}|{{url .Bad}}|{{join .Tags "、"}}`))
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]any{
+		"Text": "a <b>\n[c]",
+		"URL":  "https://example.com",
+		"Bad":  "https://example.com/\nx",
+		"Tags": []string{"x", "y"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() returned error: %v", err)
+	}
+	want := `a &lt;b&gt; [c]|[a &lt;b&gt; \[c\]](https://example.com)||x、y`
+	if got := b.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
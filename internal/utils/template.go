@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs 返回报告模板中可用的辅助函数：
+//
+//	inline  SanitizeInlineText，普通文本
+//	label   SanitizeLinkLabel，链接文本
+//	table   SanitizeTableLabel，表格单元格中的链接文本
+//	url     SanitizeURL，无效 URL 返回空字符串
+//	link    RenderSafeMarkdownLink，生成 Markdown 链接
+//	bjt     UTCToBJT，将 RFC3339 UTC 时间转换为北京时间
+//	weekday ChineseWeekday，周几
+//	join    strings.Join
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"inline": SanitizeInlineText,
+		"label":  SanitizeLinkLabel,
+		"table":  SanitizeTableLabel,
+		"url": func(raw string) string {
+			safe, _ := SanitizeURL(raw)
+			return safe
+		},
+		"link":    RenderSafeMarkdownLink,
+		"bjt":     UTCToBJT,
+		"weekday": ChineseWeekday,
+		"join":    strings.Join,
+	}
+}